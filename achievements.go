@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+type achievement struct {
+	id          string // Used to store the progress in the stats file, so mustn't change
+	name        string
+	description string
+	goal        int // Unlocked once the progress reaches this
+	// Returns the progress towards the goal after the given event
+	update func(progress int, e gameEvent) int
+}
+
+var achievements = []achievement{
+	{
+		id:          "five-in-a-row",
+		name:        "Five in a row",
+		description: "Make a match of 5 symbols",
+		goal:        5,
+		update: func(progress int, e gameEvent) int {
+			if e, ok := e.(matchEvent); ok {
+				return maxInt(progress, len(e.match))
+			}
+			return progress
+		},
+	},
+	{
+		id:          "chain-reaction",
+		name:        "Chain reaction",
+		description: "Make a cascade 4 matches deep",
+		goal:        4,
+		update: func(progress int, e gameEvent) int {
+			if e, ok := e.(matchEvent); ok {
+				return maxInt(progress, e.cascadeDepth)
+			}
+			return progress
+		},
+	},
+	{
+		id:          "high-scorer",
+		name:        "High scorer",
+		description: "Finish a Limited moves game with over 3,000 points",
+		goal:        3001, // Over 3,000, so 3,000 itself isn't enough
+		update: func(progress int, e gameEvent) int {
+			if e, ok := e.(gameOverEvent); ok && e.gameType == LimitedMoves {
+				return maxInt(progress, e.score)
+			}
+			return progress
+		},
+	},
+	{
+		id:          "no-help-needed",
+		name:        "No help needed",
+		description: "Play all the moves of a Limited moves game without showing a hint",
+		goal:        1,
+		update: func(progress int, e gameEvent) int {
+			if e, ok := e.(gameOverEvent); ok && e.gameType == LimitedMoves && e.moveCount >= moveLimit &&
+				e.hintsUsed == 0 {
+				return 1
+			}
+			return progress
+		},
+	},
+	{
+		id:          "centurion",
+		name:        "Centurion",
+		description: "Make 100 matches",
+		goal:        100,
+		update: func(progress int, e gameEvent) int {
+			if _, ok := e.(matchEvent); ok {
+				return progress + 1
+			}
+			return progress
+		},
+	},
+	{
+		id:          "regular",
+		name:        "Regular",
+		description: "Finish 10 games",
+		goal:        10,
+		update: func(progress int, e gameEvent) int {
+			if _, ok := e.(gameOverEvent); ok {
+				return progress + 1
+			}
+			return progress
+		},
+	},
+}
+
+func (a achievement) isUnlocked(s stats) bool {
+	return s.Achievements[a.id] >= a.goal
+}
+
+type achievementEvent struct {
+	achievement achievement
+}
+
+func (e achievementEvent) announcement(m model) string {
+	return fmt.Sprintf("Achievement unlocked: %s.", e.achievement.name)
+}
+
+func (e achievementEvent) logEntry() (string, eventLogFields) {
+	return "achievement", eventLogFields{"id": e.achievement.id}
+}
+
+// Updates the progress of each achievement for an event, queueing any that are unlocked so they can be shown
+// Achievements are updated as soon as the event happens (rather than at the end of the game like the other stats), so
+// they can be shown during the game
+func updateAchievements(m model, e gameEvent) model {
+	for _, a := range achievements {
+		if a.isUnlocked(m.stats) {
+			continue
+		}
+
+		progress := m.stats.Achievements[a.id]
+		updatedProgress := a.update(progress, e)
+		if updatedProgress == progress {
+			continue
+		}
+
+		progresses := maps.Clone(m.stats.Achievements)
+		if progresses == nil {
+			progresses = map[string]int{} // No progress has been made on any achievement yet
+		}
+		progresses[a.id] = updatedProgress
+		m.stats.Achievements = progresses
+
+		if a.isUnlocked(m.stats) {
+			m.unlockedAchievements = append(slices.Clip(m.unlockedAchievements), a)
+		}
+	}
+	return m
+}
+
+const toastDuration = 3 * time.Second
+
+// Maximum number of toasts shown at once; older ones are hidden
+const maxToastCount = 2
+
+// A short notification shown at the bottom of the window, which disappears by itself
+type toast struct {
+	id   int
+	text string
+}
+
+var lastToastID int64
+
+type toastExpiredMsg struct {
+	id int
+}
+
+// Shows a toast for each achievement unlocked since the last update, then saves the unlocked achievements
+// Toasts are drawn over the bottom of the window, so they don't interrupt the current view (e.g. an animation)
+func showUnlockedAchievements(m model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if len(m.unlockedAchievements) == 0 {
+		return m, cmd
+	}
+
+	cmds := []tea.Cmd{cmd}
+	for _, a := range m.unlockedAchievements {
+		m = emitGameEvent(m, achievementEvent{achievement: a})
+
+		id := int(atomic.AddInt64(&lastToastID, 1))
+		m.toasts = append(slices.Clip(m.toasts), toast{id: id, text: "🏆 Achievement unlocked: " + a.name})
+		cmds = append(cmds, tea.Tick(toastDuration, func(time.Time) tea.Msg {
+			return toastExpiredMsg{id: id}
+		}))
+	}
+	m.unlockedAchievements = nil
+
+	return m, tea.Batch(append(cmds, saveStats(m))...)
+}
+
+func removeToast(toasts []toast, id int) []toast {
+	return slices.DeleteFunc(slices.Clone(toasts), func(t toast) bool {
+		return t.id == id
+	})
+}
+
+// Draws the most recent toasts over the bottom lines of the window
+func drawToasts(m model, lines []string) []string {
+	toasts := m.toasts[maxInt(len(m.toasts)-maxToastCount, 0):]
+	if len(toasts) > len(lines) {
+		toasts = toasts[len(toasts)-len(lines):]
+	}
+
+	toastStyle := m.theme.highlightedStyle().Padding(0, 1)
+	for i, t := range toasts {
+		lines[len(lines)-len(toasts)+i] = lipgloss.PlaceHorizontal(m.windowSize.x, lipgloss.Center,
+			toastStyle.Render(t.text))
+	}
+	return lines
+}
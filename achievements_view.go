@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	"strings"
+)
+
+// Shows the achievements, returning to the current view when closed
+func showAchievementsView(m model) (tea.Model, tea.Cmd) {
+	m.help.ShowAll = false
+
+	a := achievementsView{
+		keys:       newAchievementsViewKeys(m),
+		returnView: m.view,
+	}
+	m.view = &a
+
+	return m, nil
+}
+
+type achievementsViewKeyMap struct {
+	Back key.Binding
+}
+
+func newAchievementsViewKeys(m model) achievementsViewKeyMap {
+	return achievementsViewKeyMap{
+		Back: newKeyBinding(m, achievementsBackAction, "back"),
+	}
+}
+
+func (k achievementsViewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Back}
+}
+
+func (k achievementsViewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Back},
+	}
+}
+
+type achievementsView struct {
+	keys       achievementsViewKeyMap
+	returnView view // View to return to when the achievements are closed, e.g. the title view
+}
+
+func (a *achievementsView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, a.keys.Back):
+			m.view = a.returnView
+			m.help.ShowAll = false
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+const achievementProgressBarWidth = 10
+
+func (a *achievementsView) draw(m model) string {
+	unlockedCount := 0
+	nameWidth := 0
+	for _, ach := range achievements {
+		if ach.isUnlocked(m.stats) {
+			unlockedCount++
+		}
+		nameWidth = maxInt(nameWidth, lipgloss.Width(ach.name))
+	}
+
+	entries := make([]string, 0, len(achievements))
+	for _, ach := range achievements {
+		progress := minInt(m.stats.Achievements[ach.id], ach.goal)
+		name := ach.name + strings.Repeat(" ", nameWidth-lipgloss.Width(ach.name))
+
+		// The marker shows whether the achievement is unlocked without relying on colour
+		var heading, progressText string
+		if ach.isUnlocked(m.stats) {
+			heading = m.theme.highlightedStyle().Render("[x] " + name)
+			progressText = "Unlocked"
+		} else {
+			heading = "[ ] " + name
+			filledWidth := progress * achievementProgressBarWidth / ach.goal
+			progressText = fmt.Sprintf("%s%s %s/%s", strings.Repeat("█", filledWidth),
+				strings.Repeat("░", achievementProgressBarWidth-filledWidth), humanize.Comma(int64(progress)),
+				humanize.Comma(int64(ach.goal)))
+		}
+		entries = append(entries, heading+"  "+progressText+"\n    "+m.theme.secondaryTextStyle().Render(ach.description))
+	}
+
+	m.help.Width = getMainViewWidth(m)
+	helpView := m.help.View(a.keys)
+	summary := fmt.Sprintf("%d of %d unlocked", unlockedCount, len(achievements))
+
+	if isCompactLayout(m) {
+		// Leave out the heading and spacing, so the achievements fit in small windows
+		return lipgloss.NewStyle().Width(getMainViewWidth(m)).Render(
+			lipgloss.JoinVertical(lipgloss.Left, summary, strings.Join(entries, "\n"), helpView))
+	}
+
+	return lipgloss.NewStyle().Width(getMainViewWidth(m)).Render(lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Bold(true).Render("Achievements")+"  "+m.theme.secondaryTextStyle().Render(summary),
+		"",
+		strings.Join(entries, "\n"),
+		"",
+		helpView,
+	))
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"math"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+type animationSpeed int
+
+const (
+	Slow animationSpeed = iota
+	Normal
+	Fast
+)
+
+func (s animationSpeed) String() string {
+	return [...]string{"Slow", "Normal", "Fast"}[s]
+}
+
+var animationSpeeds = []animationSpeed{Slow, Normal, Fast}
+
+// Scales a duration at normal speed to this speed
+func (s animationSpeed) scale(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * [...]float64{1.75, 1, 0.5}[s])
+}
+
+const animationFrameInterval = 40 * time.Millisecond
+
+// A symbol drawn during an animation
+// The position is in grid points, but can be between points (e.g. x = 1.5 is halfway between the second and third
+// columns)
+type sprite struct {
+	symbol      int
+	x, y        float64
+	highlighted bool
+}
+
+type animation interface {
+	duration() time.Duration // Duration at normal speed
+	// Returns the symbols to draw once the given fraction (from 0 to 1) of the animation has elapsed
+	sprites(progress float64) []sprite
+}
+
+// Used to ignore frames meant for other animations (or for the same animation before it was paused)
+var lastAnimationTag int64
+
+func newAnimationTag() int {
+	return int(atomic.AddInt64(&lastAnimationTag, 1))
+}
+
+type animationFrameMsg struct {
+	tag int
+}
+
+type animationPlayer struct {
+	animation animation
+	elapsed   time.Duration
+	duration  time.Duration // Scaled by the animation speed
+	tag       int
+}
+
+func newAnimationPlayer(m model, a animation) animationPlayer {
+	return animationPlayer{
+		animation: a,
+		elapsed:   0,
+		duration:  m.options.animationSpeed.scale(a.duration()),
+		tag:       newAnimationTag(),
+	}
+}
+
+func (p animationPlayer) nextFrame() tea.Cmd {
+	tag := p.tag
+	return tea.Tick(animationFrameInterval, func(time.Time) tea.Msg {
+		return animationFrameMsg{tag: tag}
+	})
+}
+
+// Moves the animation on by one frame
+// Returns false if the frame was meant for a different animation, in which case it should be ignored
+func (p *animationPlayer) advance(msg animationFrameMsg) bool {
+	if msg.tag != p.tag {
+		return false
+	}
+
+	p.elapsed += animationFrameInterval
+	return true
+}
+
+// Restarts the frames of an animation that was paused, e.g. by a modal being shown on top of it
+// Frames that were already scheduled are ignored, otherwise the animation would play at double speed
+func (p *animationPlayer) resume() tea.Cmd {
+	p.tag = newAnimationTag()
+	return p.nextFrame()
+}
+
+func (p animationPlayer) isFinished() bool {
+	return p.elapsed >= p.duration
+}
+
+func (p animationPlayer) progress() float64 {
+	if p.duration <= 0 {
+		return 1
+	}
+	return math.Min(float64(p.elapsed)/float64(p.duration), 1)
+}
+
+func (p animationPlayer) sprites() []sprite {
+	return p.animation.sprites(p.progress())
+}
+
+// Slows down at the start and end, so movement doesn't look mechanical
+func easeInOut(t float64) float64 {
+	return (1 - math.Cos(t*math.Pi)) / 2
+}
+
+// Speeds up towards the end, like something falling
+func easeIn(t float64) float64 {
+	return t * t
+}
+
+func lerp(from, to, t float64) float64 {
+	return from + (to-from)*t
+}
+
+func getGridSprites(g grid, highlightedPoints []vector2d) []sprite {
+	sprites := make([]sprite, 0, g.width()*g.height())
+	for y, row := range g {
+		for x, symbol := range row {
+			if symbol == emptySymbol {
+				continue
+			}
+
+			sprites = append(sprites, sprite{
+				symbol:      symbol,
+				x:           float64(x),
+				y:           float64(y),
+				highlighted: slices.Contains(highlightedPoints, vector2d{x: x, y: y}),
+			})
+		}
+	}
+	return sprites
+}
+
+// Moves two symbols into each other's places
+// If the swap would not result in a match, the symbols only move part of the way then bounce back
+type swapAnimation struct {
+	grid           grid // Before the swap
+	point1, point2 vector2d
+	bounce         bool
+}
+
+func (s swapAnimation) duration() time.Duration {
+	if s.bounce {
+		return 320 * time.Millisecond
+	}
+	return 200 * time.Millisecond
+}
+
+func (s swapAnimation) sprites(progress float64) []sprite {
+	var t float64
+	if s.bounce {
+		// Stay below halfway, otherwise the symbols would be rounded to each other's places when swapping vertically
+		t = 0.4 * easeInOut(1-math.Abs(2*progress-1))
+	} else {
+		t = easeInOut(progress)
+	}
+
+	g := s.grid.clone()
+	symbol1, symbol2 := g[s.point1.y][s.point1.x], g[s.point2.y][s.point2.x]
+	g[s.point1.y][s.point1.x], g[s.point2.y][s.point2.x] = emptySymbol, emptySymbol
+
+	sprites := getGridSprites(g, nil)
+	return append(sprites,
+		sprite{
+			symbol:      symbol1,
+			x:           lerp(float64(s.point1.x), float64(s.point2.x), t),
+			y:           lerp(float64(s.point1.y), float64(s.point2.y), t),
+			highlighted: true,
+		},
+		sprite{
+			symbol:      symbol2,
+			x:           lerp(float64(s.point2.x), float64(s.point1.x), t),
+			y:           lerp(float64(s.point2.y), float64(s.point1.y), t),
+			highlighted: true,
+		},
+	)
+}
+
+// Flashes matched symbols before they're cleared
+// With reduced motion, the symbols are highlighted without flashing
+type flashAnimation struct {
+	grid   grid
+	points []vector2d
+	steady bool
+}
+
+const flashCount = 3
+
+func (f flashAnimation) duration() time.Duration {
+	return 450 * time.Millisecond
+}
+
+func (f flashAnimation) sprites(progress float64) []sprite {
+	isVisible := f.steady || int(progress*flashCount*2)%2 == 0
+	if isVisible {
+		return getGridSprites(f.grid, f.points)
+	}
+
+	g := f.grid.clone()
+	for _, p := range f.points {
+		g[p.y][p.x] = emptySymbol
+	}
+	return getGridSprites(g, nil)
+}
+
+// Drops symbols into the points cleared by matches
+// Columns start falling one after another, from left to right
+type fallAnimation struct {
+	grid  grid // After the symbols have fallen
+	falls []symbolFall
+}
+
+// Fraction of the animation between the first and last columns starting to fall
+const fallColumnStagger = 0.4
+
+func (f fallAnimation) duration() time.Duration {
+	return 480 * time.Millisecond
+}
+
+func (f fallAnimation) sprites(progress float64) []sprite {
+	g := f.grid.clone()
+	for _, fall := range f.falls {
+		g[fall.to.y][fall.to.x] = emptySymbol
+	}
+	sprites := getGridSprites(g, nil)
+
+	for _, fall := range f.falls {
+		columnStart := fallColumnStagger * float64(fall.to.x) / float64(maxInt(f.grid.width()-1, 1))
+		columnProgress := math.Max(0, math.Min((progress-columnStart)/(1-fallColumnStagger), 1))
+		sprites = append(sprites, sprite{
+			symbol: f.grid[fall.to.y][fall.to.x],
+			x:      float64(fall.to.x),
+			y:      lerp(float64(fall.fromY), float64(fall.to.y), easeIn(columnProgress)),
+		})
+	}
+	return sprites
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+)
+
+// In bot mode, the game is played by another program (a "bot") instead of in the terminal
+// The game sends messages to the bot on its stdin and reads the bot's replies from its stdout, one JSON object per
+// line; see the README for the full protocol
+
+// Increased whenever the protocol changes in a way that could break existing bots
+const botProtocolVersion = 1
+
+const defaultBotTimeout = 10 * time.Second
+
+// A bot that keeps sending invalid moves (e.g. because of a bug) would never finish the game, so the game is ended
+// after this many invalid moves in a row
+const maxInvalidBotMoveCount = 10
+
+// Longest line the bot can send; longer lines are skipped rather than read into memory, and count as invalid moves
+const maxBotLineLength = 64 * 1024
+
+// Sent once at the start of the game
+type botHelloMessage struct {
+	Type        string `json:"type"`
+	Version     string `json:"version"`
+	Protocol    int    `json:"protocol"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	SymbolCount int    `json:"symbolCount"`
+	MoveLimit   int    `json:"moveLimit"`
+	Seed        int64  `json:"seed"`
+	TimeoutMs   int64  `json:"timeoutMs"` // How long the bot has to reply to each "state" message
+}
+
+// Sent whenever the bot should make a move
+type botStateMessage struct {
+	Type      string `json:"type"`
+	Board     grid   `json:"board"`
+	Score     int    `json:"score"`
+	MoveCount int    `json:"moveCount"`
+	MovesLeft int    `json:"movesLeft"`
+}
+
+// Sent after a valid move, once the grid has finished refreshing
+type botResultMessage struct {
+	Type         string `json:"type"`
+	Points       int    `json:"points"`
+	Score        int    `json:"score"`
+	MatchCount   int    `json:"matchCount"`
+	CascadeDepth int    `json:"cascadeDepth"`
+	Reshuffled   bool   `json:"reshuffled"` // Whether a new grid was created as there were no possible moves left
+}
+
+// Sent after an invalid move (which doesn't use up a move); the bot is then sent the same state again
+type botInvalidMessage struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+type botGameOverMessage struct {
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Score     int    `json:"score"`
+	MoveCount int    `json:"moveCount"`
+}
+
+// Types of message sent to the bot
+const (
+	botHelloMessageType    = "hello"
+	botStateMessageType    = "state"
+	botResultMessageType   = "result"
+	botInvalidMessageType  = "invalid"
+	botGameOverMessageType = "game-over"
+)
+
+// Types of command the bot can send
+const (
+	botSwapCommandType = "swap"
+	botQuitCommandType = "quit"
+)
+
+// Reasons a move can be invalid
+const (
+	invalidJSONBotMoveReason    = "invalid-json"
+	unknownCommandBotMoveReason = "unknown-command"
+	outOfBoundsBotMoveReason    = "out-of-bounds"
+	notAdjacentBotMoveReason    = "not-adjacent"
+	noMatchBotMoveReason        = "no-match"
+	lineTooLongBotMoveReason    = "line-too-long"
+)
+
+// Reasons the game can end
+const (
+	noMovesLeftBotGameOverReason  = "no-moves-left"
+	quitBotGameOverReason         = "quit"
+	timeoutBotGameOverReason      = "timeout"
+	invalidMovesBotGameOverReason = "too-many-invalid-moves"
+	inputClosedBotGameOverReason  = "input-closed"
+)
+
+// Sent by the bot in reply to a "state" message
+type botCommand struct {
+	Type string   `json:"type"`
+	From vector2d `json:"from"`
+	To   vector2d `json:"to"`
+}
+
+// A line sent by the bot, or a marker that it sent a line longer than `maxBotLineLength`
+type botLine struct {
+	text    string
+	tooLong bool
+}
+
+type botGame struct {
+	rand        *rand.Rand
+	grid        grid
+	symbolCount int
+	score       int
+	moveCount   int
+	encoder     *json.Encoder
+}
+
+// Plays a limited moves game with the bot, returning an error if the bot's input can't be written to
+func runBotGame(in io.Reader, out io.Writer, seed int64, bs boardSize, symbolCount int, timeout time.Duration) error {
+	r := rand.New(rand.NewSource(seed))
+	g := newGridWithMatchesRemoved(r, bs.dimensions(), symbolCount)
+	ensurePotentialMatch(&g, r, symbolCount)
+	b := botGame{rand: r, grid: g, symbolCount: symbolCount, encoder: json.NewEncoder(out)}
+
+	// Lines are read in the background, so the game can stop waiting if the bot takes too long to reply
+	lines := make(chan botLine)
+	done := make(chan struct{})
+	defer close(done) // Stops the reader from waiting to send lines once the game is over
+	go readBotLines(in, lines, done)
+
+	err := b.encoder.Encode(botHelloMessage{
+		Type:        botHelloMessageType,
+		Version:     version,
+		Protocol:    botProtocolVersion,
+		Width:       g.width(),
+		Height:      g.height(),
+		SymbolCount: symbolCount,
+		MoveLimit:   moveLimit,
+		Seed:        seed,
+		TimeoutMs:   timeout.Milliseconds(),
+	})
+	if err != nil {
+		return err
+	}
+
+	invalidMoveCount := 0
+	for b.moveCount < moveLimit {
+		err := b.encoder.Encode(botStateMessage{
+			Type:      botStateMessageType,
+			Board:     b.grid,
+			Score:     b.score,
+			MoveCount: b.moveCount,
+			MovesLeft: moveLimit - b.moveCount,
+		})
+		if err != nil {
+			return err
+		}
+
+		var line botLine
+		var ok bool
+		select {
+		case line, ok = <-lines:
+			if !ok {
+				return b.endGame(inputClosedBotGameOverReason)
+			}
+		case <-time.After(timeout):
+			return b.endGame(timeoutBotGameOverReason)
+		}
+
+		var c botCommand
+		var invalidReason string
+		if line.tooLong {
+			invalidReason = lineTooLongBotMoveReason
+		} else if err := json.Unmarshal([]byte(line.text), &c); err != nil {
+			invalidReason = invalidJSONBotMoveReason
+		} else if c.Type == botQuitCommandType {
+			return b.endGame(quitBotGameOverReason)
+		} else if c.Type != botSwapCommandType {
+			invalidReason = unknownCommandBotMoveReason
+		} else {
+			invalidReason = b.validateSwap(c.From, c.To)
+		}
+
+		if invalidReason != "" {
+			invalidMoveCount++
+			if invalidMoveCount >= maxInvalidBotMoveCount {
+				return b.endGame(invalidMovesBotGameOverReason)
+			}
+			if err := b.encoder.Encode(botInvalidMessage{Type: botInvalidMessageType, Reason: invalidReason}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		invalidMoveCount = 0
+		if err := b.encoder.Encode(b.swap(c.From, c.To)); err != nil {
+			return err
+		}
+	}
+
+	return b.endGame(noMovesLeftBotGameOverReason)
+}
+
+// Reads lines from the bot until its output is closed (or can't be read), or the game is over
+func readBotLines(in io.Reader, lines chan<- botLine, done <-chan struct{}) {
+	defer close(lines)
+
+	send := func(line botLine) bool {
+		select {
+		case lines <- line:
+			return true
+		case <-done:
+			return false
+		}
+	}
+
+	reader := bufio.NewReaderSize(in, maxBotLineLength)
+	for {
+		line, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return
+		}
+		if !isPrefix {
+			if !send(botLine{text: string(line)}) {
+				return
+			}
+			continue
+		}
+
+		// The line didn't fit in the buffer, so skip the rest of it
+		for isPrefix && err == nil {
+			_, isPrefix, err = reader.ReadLine()
+		}
+		if !send(botLine{tooLong: true}) {
+			return
+		}
+	}
+}
+
+// Returns why swapping the points isn't allowed, or an empty string if it is
+func (b *botGame) validateSwap(p1, p2 vector2d) string {
+	if !b.grid.contains(p1) || !b.grid.contains(p2) {
+		return outOfBoundsBotMoveReason
+	}
+	if !arePointsAdjacent(p1, p2) {
+		return notAdjacentBotMoveReason
+	}
+
+	updatedGrid := b.grid.clone()
+	updatedGrid[p1.y][p1.x], updatedGrid[p2.y][p2.x] = updatedGrid[p2.y][p2.x], updatedGrid[p1.y][p1.x]
+	if len(findMatches(updatedGrid)) == 0 {
+		return noMatchBotMoveReason
+	}
+	return ""
+}
+
+// Swaps the points and refreshes the grid, in the same way as in the terminal so the same seed gives the same game
+func (b *botGame) swap(p1, p2 vector2d) botResultMessage {
+	b.grid = b.grid.clone()
+	b.grid[p1.y][p1.x], b.grid[p2.y][p2.x] = b.grid[p2.y][p2.x], b.grid[p1.y][p1.x]
+	b.moveCount++
+
+	result := botResultMessage{Type: botResultMessageType}
+	previousScore := b.score
+	for {
+		finished, matches := refreshGrid(&b.grid, b.rand, b.symbolCount, &b.score)
+		if finished {
+			break
+		}
+		if matches != nil {
+			result.CascadeDepth++
+			result.MatchCount += len(matches)
+		}
+	}
+
+	if len(findPotentialMatch(b.grid)) == 0 {
+		ensurePotentialMatch(&b.grid, b.rand, b.symbolCount)
+		result.Reshuffled = true
+	}
+
+	result.Points = b.score - previousScore
+	result.Score = b.score
+	return result
+}
+
+func (b *botGame) endGame(reason string) error {
+	err := b.encoder.Encode(botGameOverMessage{
+		Type:      botGameOverMessageType,
+		Reason:    reason,
+		Score:     b.score,
+		MoveCount: b.moveCount,
+	})
+	if err != nil {
+		return fmt.Errorf("could not send game over: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Replies worked out from the board in the "state" message, rather than sent as they are
+const (
+	validSwapBotReply   = "valid-swap"
+	noMatchSwapBotReply = "no-match-swap"
+)
+
+// Any message sent to the bot, with the fields the tests check
+type botTestMessage struct {
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Board     grid   `json:"board"`
+	MoveCount int    `json:"moveCount"`
+}
+
+// Returns a swap of neighbouring points that does (or doesn't) make a match
+func findBotTestSwap(g grid, match bool) string {
+	for y := range g {
+		for x := range g[y] {
+			for _, p2 := range []vector2d{{x: x + 1, y: y}, {x: x, y: y + 1}} {
+				if !g.contains(p2) {
+					continue
+				}
+				updatedGrid := g.clone()
+				updatedGrid[y][x], updatedGrid[p2.y][p2.x] = updatedGrid[p2.y][p2.x], updatedGrid[y][x]
+				if (len(findMatches(updatedGrid)) != 0) == match {
+					return fmt.Sprintf(`{"type": "swap", "from": {"x": %d, "y": %d}, "to": {"x": %d, "y": %d}}`, x,
+						y, p2.x, p2.y)
+				}
+			}
+		}
+	}
+	panic("no swap found")
+}
+
+func repeatString(s string, count int) []string {
+	replies := make([]string, count)
+	for i := range replies {
+		replies[i] = s
+	}
+	return replies
+}
+
+// Plays a game with a bot that sends the replies in order, one per "state" message, then closes its output (or if
+// `wait`, stops replying), returning the messages sent to the bot
+func playBotTestGame(t *testing.T, replies []string, wait bool) []botTestMessage {
+	inReader, inWriter := io.Pipe()
+	outReader, outWriter := io.Pipe()
+	defer inWriter.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- runBotGame(inReader, outWriter, 1, Medium, 6, 100*time.Millisecond)
+		outWriter.Close()
+	}()
+
+	var messages []botTestMessage
+	scanner := bufio.NewScanner(outReader)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var message botTestMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatalf("invalid message %q: %v", scanner.Text(), err)
+		}
+		messages = append(messages, message)
+		if message.Type != botStateMessageType {
+			continue
+		}
+
+		if len(replies) == 0 {
+			if !wait {
+				inWriter.Close()
+			}
+			continue
+		}
+		reply := replies[0]
+		replies = replies[1:]
+		switch reply {
+		case validSwapBotReply:
+			reply = findBotTestSwap(message.Board, true)
+		case noMatchSwapBotReply:
+			reply = findBotTestSwap(message.Board, false)
+		}
+		if _, err := io.WriteString(inWriter, reply+"\n"); err != nil {
+			t.Fatalf("could not send reply: %v", err)
+		}
+	}
+
+	if err := <-errs; err != nil {
+		t.Fatalf("runBotGame returned an error: %v", err)
+	}
+	return messages
+}
+
+func TestRunBotGame(t *testing.T) {
+	const quitReply = `{"type": "quit"}`
+
+	tests := []struct {
+		name           string
+		replies        []string
+		wait           bool
+		invalidReasons []string
+		gameOverReason string
+		moveCount      int
+	}{
+		{
+			name:           "invalid JSON",
+			replies:        []string{`{"type": "swap"`, quitReply},
+			invalidReasons: []string{invalidJSONBotMoveReason},
+			gameOverReason: quitBotGameOverReason,
+		},
+		{
+			name:           "unknown command",
+			replies:        []string{`{"type": "undo"}`, quitReply},
+			invalidReasons: []string{unknownCommandBotMoveReason},
+			gameOverReason: quitBotGameOverReason,
+		},
+		{
+			name:           "out of bounds",
+			replies:        []string{`{"type": "swap", "from": {"x": -1, "y": 0}, "to": {"x": 0, "y": 0}}`, quitReply},
+			invalidReasons: []string{outOfBoundsBotMoveReason},
+			gameOverReason: quitBotGameOverReason,
+		},
+		{
+			name:           "not adjacent",
+			replies:        []string{`{"type": "swap", "from": {"x": 0, "y": 0}, "to": {"x": 2, "y": 0}}`, quitReply},
+			invalidReasons: []string{notAdjacentBotMoveReason},
+			gameOverReason: quitBotGameOverReason,
+		},
+		{
+			name:           "no match",
+			replies:        []string{noMatchSwapBotReply, quitReply},
+			invalidReasons: []string{noMatchBotMoveReason},
+			gameOverReason: quitBotGameOverReason,
+		},
+		{
+			name: "line too long",
+			replies: []string{`{"type": "quit", "padding": "` + strings.Repeat("x", maxBotLineLength) + `"}`,
+				validSwapBotReply, quitReply},
+			invalidReasons: []string{lineTooLongBotMoveReason},
+			gameOverReason: quitBotGameOverReason,
+			moveCount:      1,
+		},
+		{
+			name:           "no moves left",
+			replies:        repeatString(validSwapBotReply, moveLimit),
+			gameOverReason: noMovesLeftBotGameOverReason,
+			moveCount:      moveLimit,
+		},
+		{
+			name:           "quit",
+			replies:        []string{validSwapBotReply, quitReply},
+			gameOverReason: quitBotGameOverReason,
+			moveCount:      1,
+		},
+		{
+			name:           "timeout",
+			replies:        []string{validSwapBotReply},
+			wait:           true,
+			gameOverReason: timeoutBotGameOverReason,
+			moveCount:      1,
+		},
+		{
+			name:           "too many invalid moves",
+			replies:        repeatString(`{"type": "undo"}`, maxInvalidBotMoveCount),
+			invalidReasons: repeatString(unknownCommandBotMoveReason, maxInvalidBotMoveCount-1),
+			gameOverReason: invalidMovesBotGameOverReason,
+		},
+		{
+			name:           "input closed",
+			replies:        []string{validSwapBotReply},
+			gameOverReason: inputClosedBotGameOverReason,
+			moveCount:      1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := playBotTestGame(t, tt.replies, tt.wait)
+
+			if messages[0].Type != botHelloMessageType {
+				t.Errorf("first message is %q, want %q", messages[0].Type, botHelloMessageType)
+			}
+
+			var invalidReasons []string
+			for _, message := range messages {
+				if message.Type == botInvalidMessageType {
+					invalidReasons = append(invalidReasons, message.Reason)
+				}
+			}
+			if !slices.Equal(invalidReasons, tt.invalidReasons) {
+				t.Errorf("invalid reasons are %q, want %q", invalidReasons, tt.invalidReasons)
+			}
+
+			gameOver := messages[len(messages)-1]
+			if gameOver.Type != botGameOverMessageType {
+				t.Fatalf("last message is %q, want %q", gameOver.Type, botGameOverMessageType)
+			}
+			if gameOver.Reason != tt.gameOverReason {
+				t.Errorf("game over reason is %q, want %q", gameOver.Reason, tt.gameOverReason)
+			}
+			if gameOver.MoveCount != tt.moveCount {
+				t.Errorf("move count is %d, want %d", gameOver.MoveCount, tt.moveCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const configDirName = "match-three-game"
+const configFileName = "config.json"
+const themesDirName = "themes"
+
+// Preferences are stored using the names shown in the UI, e.g. "Limited moves"
+// Empty (or missing) entries use the defaults
+type config struct {
+	GameType     string `json:"gameType,omitempty"`
+	PlayerCount  int    `json:"playerCount,omitempty"`
+	HotSeatBoard string `json:"hotSeatBoard,omitempty"`
+	SymbolSet    string `json:"symbolSet,omitempty"`
+	BoardSize    string `json:"boardSize,omitempty"`
+	SymbolCount  int    `json:"symbolCount,omitempty"`
+	Theme        string `json:"theme,omitempty"`
+	HintPolicy   string `json:"hintPolicy,omitempty"`
+	InputMode    string `json:"inputMode,omitempty"`
+	ConfirmMoves *bool  `json:"confirmMoves,omitempty"`
+	// Accessibility mode marks symbols without relying on colour alone, and announces game events in words
+	AccessibilityMode *bool `json:"accessibilityMode,omitempty"`
+	// File to append announcements of game events to, e.g. for a screen reader to follow
+	AnnouncementLog string `json:"announcementLog,omitempty"`
+	// File to append game events to as JSON lines, e.g. for analysing games afterwards
+	EventLog       string `json:"eventLog,omitempty"`
+	AnimationSpeed string `json:"animationSpeed,omitempty"`
+	// Reduced motion replaces moving and flashing animations with highlighting
+	ReducedMotion *bool      `json:"reducedMotion,omitempty"`
+	Keys          keysConfig `json:"keys"`
+	// Custom symbol sets, shown after the built-in symbol sets
+	SymbolSets []symbolSetConfig `json:"symbolSets,omitempty"`
+
+	// Custom themes, which are loaded from separate files in the themes directory rather than the config file itself
+	Themes    []themeConfig `json:"-"`
+	themesErr error
+}
+
+type keysConfig struct {
+	// Name of the built-in key profile to start from, e.g. "vim"
+	Profile string `json:"profile,omitempty"`
+	// Actions to remap, e.g. {"game.toggle-hint": ["H"]}; these override the profile's keys
+	Bindings map[string][]string `json:"bindings,omitempty"`
+}
+
+type symbolSetConfig struct {
+	Name    string        `json:"name"`
+	Symbols []string      `json:"symbols"`
+	Colors  []colorConfig `json:"colors,omitempty"`
+}
+
+type themeConfig struct {
+	Name                string        `json:"name"`
+	Monochrome          bool          `json:"monochrome,omitempty"`
+	HighlightBackground *colorConfig  `json:"highlightBackground,omitempty"`
+	HighlightForeground *colorConfig  `json:"highlightForeground,omitempty"`
+	Accent              *colorConfig  `json:"accent,omitempty"`
+	HelpKey             *colorConfig  `json:"helpKey,omitempty"`
+	HelpDescription     *colorConfig  `json:"helpDescription,omitempty"`
+	HelpSeparator       *colorConfig  `json:"helpSeparator,omitempty"`
+	SymbolColors        []colorConfig `json:"symbolColors,omitempty"`
+}
+
+type colorConfig struct {
+	Light string `json:"light"`
+	Dark  string `json:"dark"`
+}
+
+// Returns the path of the config file in the user's config directory (e.g. `$XDG_CONFIG_HOME` on Linux)
+func getConfigPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, configDirName, configFileName), nil
+}
+
+// Loads the config file, along with any custom themes; if the config file doesn't exist then the default config is
+// returned
+func loadConfig() (config, error) {
+	var c config
+
+	configPath, err := getConfigPath()
+	if err != nil {
+		return c, fmt.Errorf("could not find config directory: %w", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return c, fmt.Errorf("could not read config file: %w", err)
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(data, &c); err != nil {
+			return c, fmt.Errorf("invalid config file %s: %w", configPath, err)
+		}
+	}
+
+	c.Themes, c.themesErr = loadThemeConfigs(filepath.Join(filepath.Dir(configPath), themesDirName))
+
+	return c, nil
+}
+
+// Loads each JSON file in the themes directory as a theme
+// Files that can't be loaded are skipped and returned as a combined error, so they don't prevent other themes loading
+func loadThemeConfigs(themesDir string) ([]themeConfig, error) {
+	themePaths, err := filepath.Glob(filepath.Join(themesDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	themes := make([]themeConfig, 0, len(themePaths))
+	errs := make([]error, 0)
+	for _, themePath := range themePaths {
+		data, err := os.ReadFile(themePath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not read theme file: %w", err))
+			continue
+		}
+
+		var t themeConfig
+		if err := json.Unmarshal(data, &t); err != nil {
+			errs = append(errs, fmt.Errorf("invalid theme file %s: %w", themePath, err))
+			continue
+		}
+
+		themes = append(themes, t)
+	}
+
+	return themes, errors.Join(errs...)
+}
+
+// Writes the config file, creating the config directory if needed
+func writeConfig(c config) error {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return fmt.Errorf("could not find config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+	if err := os.WriteFile(configPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("could not write config file: %w", err)
+	}
+
+	return nil
+}
+
+type configErrorMsg struct {
+	err error
+}
+
+// Copies the current preferences into the config and writes it to the config file
+// The config isn't written if it couldn't be loaded, to avoid overwriting the user's (invalid) config file
+func saveConfig(m model) (tea.Model, tea.Cmd) {
+	m.config.GameType = m.options.gameType.String()
+	m.config.PlayerCount = m.options.playerCount
+	m.config.HotSeatBoard = m.options.hotSeatBoard.String()
+	m.config.SymbolSet = m.symbolSet.String()
+	m.config.BoardSize = m.options.boardSize.String()
+	m.config.SymbolCount = m.options.symbolCount
+	m.config.Theme = m.theme.String()
+	m.config.HintPolicy = m.options.hintPolicy.String()
+	m.config.InputMode = m.options.inputMode.String()
+	m.config.AnimationSpeed = m.options.animationSpeed.String()
+	confirmMoves := m.options.confirmMoves
+	m.config.ConfirmMoves = &confirmMoves
+
+	if m.configReadOnly {
+		return m, nil
+	}
+
+	c := m.config
+	return m, func() tea.Msg {
+		if err := writeConfig(c); err != nil {
+			return configErrorMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// Applies the preferences in the config to the model
+// Invalid entries are skipped, so the defaults are used for those, and are returned as a combined error
+func applyConfig(m model, c config) (model, error) {
+	errs := make([]error, 0)
+
+	// Load custom symbol sets first, so they can be chosen as the default symbol set
+	for _, symbolSetConfig := range c.SymbolSets {
+		ss, err := newCustomSymbolSet(symbolSetConfig)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, exists := findByName(m.symbolSets, ss.String()); exists {
+			errs = append(errs, fmt.Errorf("there is already a symbol set named %q", ss.String()))
+			continue
+		}
+
+		m.symbolSets = append(m.symbolSets, ss)
+	}
+
+	if c.themesErr != nil {
+		errs = append(errs, c.themesErr)
+	}
+	for _, themeConfig := range c.Themes {
+		t, err := newCustomTheme(themeConfig)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, exists := findByName(m.themes, t.String()); exists {
+			errs = append(errs, fmt.Errorf("there is already a theme named %q", t.String()))
+			continue
+		}
+
+		m.themes = append(m.themes, t)
+	}
+
+	if c.GameType != "" {
+		if gt, ok := findByName(gameTypes, c.GameType); ok {
+			m.options.gameType = gt
+		} else {
+			errs = append(errs, newInvalidConfigValueError("gameType", c.GameType, gameTypes))
+		}
+	}
+
+	if c.PlayerCount != 0 {
+		if slices.Contains(playerCounts, c.PlayerCount) {
+			m.options.playerCount = c.PlayerCount
+		} else {
+			errs = append(errs, fmt.Errorf("invalid playerCount %d (expected one of: %s)", c.PlayerCount,
+				formatInts(playerCounts)))
+		}
+	}
+
+	if c.HotSeatBoard != "" {
+		if hb, ok := findByName(hotSeatBoards, c.HotSeatBoard); ok {
+			m.options.hotSeatBoard = hb
+		} else {
+			errs = append(errs, newInvalidConfigValueError("hotSeatBoard", c.HotSeatBoard, hotSeatBoards))
+		}
+	}
+
+	if c.SymbolSet != "" {
+		if ss, ok := findByName(m.symbolSets, c.SymbolSet); ok {
+			m.symbolSet = ss
+		} else {
+			errs = append(errs, newInvalidConfigValueError("symbolSet", c.SymbolSet, m.symbolSets))
+		}
+	}
+
+	if c.BoardSize != "" {
+		if bs, ok := findByName(boardSizes, c.BoardSize); ok {
+			m.options.boardSize = bs
+		} else {
+			errs = append(errs, newInvalidConfigValueError("boardSize", c.BoardSize, boardSizes))
+		}
+	}
+
+	if c.SymbolCount != 0 {
+		if slices.Contains(symbolCounts, c.SymbolCount) {
+			m.options.symbolCount = c.SymbolCount
+		} else {
+			errs = append(errs, fmt.Errorf("invalid symbolCount %d (expected one of: %s)", c.SymbolCount,
+				formatInts(symbolCounts)))
+		}
+	}
+
+	if c.Theme != "" {
+		if t, ok := findByName(m.themes, c.Theme); ok {
+			m = applyTheme(m, t)
+		} else {
+			errs = append(errs, newInvalidConfigValueError("theme", c.Theme, m.themes))
+		}
+	}
+
+	if c.HintPolicy != "" {
+		if hp, ok := findByName(hintPolicies, c.HintPolicy); ok {
+			m.options.hintPolicy = hp
+		} else {
+			errs = append(errs, newInvalidConfigValueError("hintPolicy", c.HintPolicy, hintPolicies))
+		}
+	}
+
+	if c.InputMode != "" {
+		if im, ok := findByName(inputModes, c.InputMode); ok {
+			m.options.inputMode = im
+		} else {
+			errs = append(errs, newInvalidConfigValueError("inputMode", c.InputMode, inputModes))
+		}
+	}
+
+	if c.ConfirmMoves != nil {
+		m.options.confirmMoves = *c.ConfirmMoves
+	}
+
+	if c.AccessibilityMode != nil {
+		m.options.accessible = *c.AccessibilityMode
+	}
+
+	if c.AnimationSpeed != "" {
+		if s, ok := findByName(animationSpeeds, c.AnimationSpeed); ok {
+			m.options.animationSpeed = s
+		} else {
+			errs = append(errs, newInvalidConfigValueError("animationSpeed", c.AnimationSpeed, animationSpeeds))
+		}
+	}
+
+	if c.ReducedMotion != nil {
+		m.options.reducedMotion = *c.ReducedMotion
+	}
+
+	if keys, err := newKeyBindings(c.Keys); err == nil {
+		m.keys = keys
+	} else {
+		errs = append(errs, err)
+	}
+
+	return m, errors.Join(errs...)
+}
+
+func findByName[T fmt.Stringer](items []T, name string) (T, bool) {
+	index := slices.IndexFunc(items, func(item T) bool {
+		return item.String() == name
+	})
+	if index == -1 {
+		var zero T
+		return zero, false
+	}
+
+	return items[index], true
+}
+
+func newInvalidConfigValueError[T fmt.Stringer](entry string, value string, validValues []T) error {
+	names := make([]string, 0, len(validValues))
+	for _, v := range validValues {
+		names = append(names, fmt.Sprintf("%q", v.String()))
+	}
+	return fmt.Errorf("invalid %s %q (expected one of: %s)", entry, value, strings.Join(names, ", "))
+}
+
+func formatInts(values []int) string {
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		strs = append(strs, strconv.Itoa(v))
+	}
+	return strings.Join(strs, ", ")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func showConfigErrorView(m model, err error) (tea.Model, tea.Cmd) {
+	return showModal(m, newConfigErrorView(m, err))
+}
+
+type configErrorViewKeyMap struct {
+	Continue key.Binding
+}
+
+func newConfigErrorViewKeys(m model) configErrorViewKeyMap {
+	return configErrorViewKeyMap{
+		Continue: newKeyBinding(m, configErrorContinueAction, "continue"),
+	}
+}
+
+func (c configErrorViewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{c.Continue}
+}
+
+func (c configErrorViewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{c.Continue},
+	}
+}
+
+type configErrorView struct {
+	err  error
+	keys configErrorViewKeyMap
+}
+
+func newConfigErrorView(m model, err error) configErrorView {
+	return configErrorView{
+		err:  err,
+		keys: newConfigErrorViewKeys(m),
+	}
+}
+
+func (c configErrorView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, c.keys.Continue):
+			return showPreviousView(m)
+		}
+	}
+
+	return m, nil
+}
+
+func (c configErrorView) draw(m model) string {
+	var savingText string
+	if m.configReadOnly {
+		savingText = "Changes won't be saved until the config file is fixed."
+	} else {
+		savingText = "The defaults will be used for anything that couldn't be loaded."
+	}
+	text := fmt.Sprintf("There was a problem with the config file:\n\n%v\n\n%s", c.err, savingText)
+
+	m.help.Width = getMainViewWidth(m)
+	helpView := m.help.View(c.keys)
+
+	return lipgloss.NewStyle().
+		Width(getMainViewWidth(m)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, text, "", helpView))
+}
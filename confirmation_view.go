@@ -11,15 +11,11 @@ type confirmationViewKeyMap struct {
 	Cancel  key.Binding
 }
 
-var confirmationViewKeys = confirmationViewKeyMap{
-	Confirm: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("↵", "confirm"),
-	),
-	Cancel: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "cancel"),
-	),
+func newConfirmationViewKeys(m model) confirmationViewKeyMap {
+	return confirmationViewKeyMap{
+		Confirm: newKeyBinding(m, confirmationConfirmAction, "confirm"),
+		Cancel:  newKeyBinding(m, confirmationCancelAction, "cancel"),
+	}
 }
 
 func (c confirmationViewKeyMap) ShortHelp() []key.Binding {
@@ -53,14 +49,14 @@ func (c confirmationView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 }
 
 func (c confirmationView) draw(m model) string {
-	m.help.Width = m.windowSize.x - 8
+	m.help.Width = getMainViewWidth(m)
 	helpView := m.help.View(c.keys)
 	return lipgloss.NewStyle().
-		Width(m.windowSize.x - 8).
+		Width(getMainViewWidth(m)).
 		Render(lipgloss.JoinVertical(lipgloss.Left, c.text, "", helpView))
 }
 
-func newQuitConfirmationView() quitConfirmationView {
+func newQuitConfirmationView(m model) quitConfirmationView {
 	const text = "Are you sure you want to quit?"
 	confirmAction := func(m model) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
@@ -68,7 +64,7 @@ func newQuitConfirmationView() quitConfirmationView {
 	q := quitConfirmationView{
 		confirmationView: confirmationView{
 			text:          text,
-			keys:          confirmationViewKeys,
+			keys:          newConfirmationViewKeys(m),
 			confirmAction: confirmAction,
 		},
 	}
@@ -80,23 +76,20 @@ func newQuitConfirmationView() quitConfirmationView {
 }
 
 func showQuitConfirmationView(m model) (tea.Model, tea.Cmd) {
-	return showModal(m, newQuitConfirmationView())
+	return showModal(m, newQuitConfirmationView(m))
 }
 
 type quitConfirmationView struct {
 	confirmationView
 }
 
-func newEndGameConfirmationView() endGameConfirmationView {
+func newEndGameConfirmationView(m model) endGameConfirmationView {
 	const text = "Are you sure you want to end the game?\n\nAny game progress will be lost."
-	confirmAction := func(m model) (tea.Model, tea.Cmd) {
-		return showGameOverView(m, "You ended the game.")
-	}
 	q := endGameConfirmationView{
 		confirmationView: confirmationView{
 			text:          text,
-			keys:          confirmationViewKeys,
-			confirmAction: confirmAction,
+			keys:          newConfirmationViewKeys(m),
+			confirmAction: endGame,
 		},
 	}
 
@@ -107,7 +100,11 @@ func newEndGameConfirmationView() endGameConfirmationView {
 }
 
 func showEndGameConfirmationView(m model) (tea.Model, tea.Cmd) {
-	return showModal(m, newEndGameConfirmationView())
+	return showModal(m, newEndGameConfirmationView(m))
+}
+
+func endGame(m model) (tea.Model, tea.Cmd) {
+	return showGameOverView(m, "You ended the game.")
 }
 
 type endGameConfirmationView struct {
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dustin/go-humanize"
+	"hash/fnv"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Daily challenges use the date in UTC, so everyone gets the same board on the same day wherever they are
+const dailyDateLayout = "2006-01-02"
+
+// Daily challenges always use the same board size and symbol count, so everyone's scores are comparable
+const dailyBoardSize = Medium
+const dailySymbolCount = maxSymbolCount
+
+// Result of the ranked (i.e. first) attempt at a daily challenge
+type dailyResult struct {
+	Score     int  `json:"score"`
+	MoveCount int  `json:"moveCount"`
+	Finished  bool `json:"finished"` // False if the game was ended before all the moves were played
+}
+
+func getDailyDate(t time.Time) string {
+	return t.UTC().Format(dailyDateLayout)
+}
+
+// Seeds the random number generator from the date, so the board and the symbols that refill it are the same for
+// everyone
+func getDailySeed(date string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("match-three-game daily " + date))
+	return int64(h.Sum64())
+}
+
+// Starts the daily challenge; only the first attempt each day is ranked, and later attempts are just for practice
+// The attempt is recorded as soon as it starts, so a bad start can't be abandoned and retried
+func startDailyChallenge(m model) (model, tea.Cmd) {
+	m.dailyDate = getDailyDate(time.Now())
+	m.gameSeed = getDailySeed(m.dailyDate)
+
+	_, played := m.stats.DailyResults[m.dailyDate]
+	m.dailyRanked = !played
+	if !m.dailyRanked {
+		return m, nil
+	}
+
+	m.stats.DailyResults = withDailyResult(m.stats.DailyResults, m.dailyDate, dailyResult{})
+	return m, saveStats(m)
+}
+
+func withDailyResult(results map[string]dailyResult, date string, result dailyResult) map[string]dailyResult {
+	updatedResults := maps.Clone(results)
+	if updatedResults == nil {
+		updatedResults = map[string]dailyResult{} // No daily challenges have been played yet
+	}
+	updatedResults[date] = result
+	return updatedResults
+}
+
+// Returns the number of days in a row that the daily challenge has been played, up to today (or yesterday, if today's
+// challenge hasn't been played yet, so the streak isn't lost until the day is over), along with the longest streak
+func getDailyStreaks(results map[string]dailyResult, today string) (current int, best int) {
+	dates := make([]time.Time, 0, len(results))
+	for d := range results {
+		if t, err := time.Parse(dailyDateLayout, d); err == nil {
+			dates = append(dates, t)
+		}
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	streak := 0
+	for i, d := range dates {
+		if i > 0 && d.Sub(dates[i-1]) == 24*time.Hour {
+			streak++
+		} else {
+			streak = 1
+		}
+		best = maxInt(best, streak)
+	}
+
+	todayTime, err := time.Parse(dailyDateLayout, today)
+	if err != nil {
+		return 0, best
+	}
+	if _, played := results[today]; !played {
+		todayTime = todayTime.AddDate(0, 0, -1)
+	}
+	for {
+		if _, played := results[todayTime.Format(dailyDateLayout)]; !played {
+			break
+		}
+		current++
+		todayTime = todayTime.AddDate(0, 0, -1)
+	}
+
+	return current, best
+}
+
+func formatDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+// Summary of the daily challenge that can be shared with others, with a square for each move showing how well it
+// scored, e.g. "🟩" for a long match
+func drawDailySummary(m model, gameStats stats) string {
+	if !m.dailyRanked {
+		return "This was a practice attempt, as only the first attempt each day is ranked."
+	}
+
+	current, best := getDailyStreaks(m.stats.DailyResults, m.dailyDate)
+
+	lines := []string{
+		fmt.Sprintf("Match Three Daily %s", m.dailyDate),
+		fmt.Sprintf("Score: %s in %d moves", humanize.Comma(int64(m.score)), m.moveCount),
+		fmt.Sprintf("Biggest cascade: %d", gameStats.BiggestCascade),
+		fmt.Sprintf("Streak: %s (best: %s)", formatDays(current), formatDays(best)),
+	}
+	if len(m.movePoints) > 0 {
+		lines = append(lines, drawMoveSquares(m.movePoints))
+	}
+	return strings.Join(append(lines, fmt.Sprintf("Seed: %d", m.gameSeed)), "\n")
+}
+
+// Describes today's daily challenge, e.g. whether the ranked attempt has been used
+func drawDailyStatus(m model) string {
+	today := getDailyDate(time.Now())
+	current, _ := getDailyStreaks(m.stats.DailyResults, today)
+
+	var text string
+	if result, played := m.stats.DailyResults[today]; played {
+		text = fmt.Sprintf("Today's challenge is done (score: %s); replays are for practice.",
+			humanize.Comma(int64(result.Score)))
+	} else {
+		text = "Today's challenge hasn't been played yet."
+	}
+	if current > 0 {
+		text += fmt.Sprintf(" Streak: %s.", formatDays(current))
+	}
+	return text
+}
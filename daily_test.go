@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Returns results for each of the dates, as only the dates matter for streaks
+func newDailyTestResults(dates ...string) map[string]dailyResult {
+	results := make(map[string]dailyResult, len(dates))
+	for _, date := range dates {
+		results[date] = dailyResult{}
+	}
+	return results
+}
+
+func TestGetDailyStreaks(t *testing.T) {
+	const today = "2024-07-01"
+
+	tests := []struct {
+		name    string
+		dates   []string
+		current int
+		best    int
+	}{
+		{
+			name: "never played",
+		},
+		{
+			name:    "only today",
+			dates:   []string{"2024-07-01"},
+			current: 1,
+			best:    1,
+		},
+		{
+			name:    "consecutive days up to today",
+			dates:   []string{"2024-06-29", "2024-06-30", "2024-07-01"},
+			current: 3,
+			best:    3,
+		},
+		{
+			name:    "today not played yet",
+			dates:   []string{"2024-06-29", "2024-06-30"},
+			current: 2,
+			best:    2,
+		},
+		{
+			name:    "yesterday missed",
+			dates:   []string{"2024-06-28", "2024-06-29"},
+			current: 0,
+			best:    2,
+		},
+		{
+			name:    "gap resets the current streak",
+			dates:   []string{"2024-06-25", "2024-06-26", "2024-06-27", "2024-06-29", "2024-06-30", "2024-07-01"},
+			current: 3,
+			best:    3,
+		},
+		{
+			name:    "best streak before a gap",
+			dates:   []string{"2024-06-20", "2024-06-21", "2024-06-22", "2024-06-23", "2024-06-30", "2024-07-01"},
+			current: 2,
+			best:    4,
+		},
+		{
+			name:    "across the end of a year",
+			dates:   []string{"2023-12-31", "2024-01-01", "2024-07-01"},
+			current: 1,
+			best:    2,
+		},
+		{
+			name:    "invalid dates ignored",
+			dates:   []string{"2024-06-30", "not a date", "2024-07-01"},
+			current: 2,
+			best:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, best := getDailyStreaks(newDailyTestResults(tt.dates...), today)
+			if current != tt.current {
+				t.Errorf("current streak is %d, want %d", current, tt.current)
+			}
+			if best != tt.best {
+				t.Errorf("best streak is %d, want %d", best, tt.best)
+			}
+		})
+	}
+}
+
+func TestGetDailyDate(t *testing.T) {
+	// Late in the evening west of UTC, it's already the next day in UTC
+	evening := time.Date(2024, 6, 30, 22, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+	if date := getDailyDate(evening); date != "2024-07-01" {
+		t.Errorf("daily date is %q, want %q", date, "2024-07-01")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dustin/go-humanize"
-	"slices"
 	"strings"
 )
 
@@ -14,14 +13,17 @@ type radioButtonItem interface {
 	String() string
 }
 
-func drawRadioButtons[T radioButtonItem](options []T, selected T, label string, key key.Binding) string {
+// Draws the options with the selected option highlighted
+// If `short`, the key is shown without a description to save space (e.g. "(t)" rather than "(press t to change)")
+func drawRadioButtons[T radioButtonItem](options []T, selected T, label string, key key.Binding, t theme,
+	short bool) string {
 	var builder strings.Builder
 	builder.WriteString(label)
 	builder.WriteString(":  ")
 	for _, option := range options {
 		var style lipgloss.Style
 		if option == selected {
-			style = highlightedStyle
+			style = t.highlightedStyle()
 		} else {
 			style = lipgloss.NewStyle()
 		}
@@ -31,7 +33,12 @@ func drawRadioButtons[T radioButtonItem](options []T, selected T, label string,
 	}
 
 	keyString := key.Help().Key
-	styledKeyString := lipgloss.NewStyle().Inherit(secondaryTextStyle).Bold(true).Render(keyString)
+	secondaryTextStyle := t.secondaryTextStyle()
+	styledKeyString := secondaryTextStyle.Copy().Bold(true).Render(keyString)
+	if short {
+		builder.WriteString(secondaryTextStyle.Render("(") + styledKeyString + secondaryTextStyle.Render(")"))
+		return builder.String()
+	}
 	// Couldn't get styling to work correctly with `fmt.Sprintf`, hence styling each substring separately then
 	// concatenating
 	keyDescription := secondaryTextStyle.Render("(press ") + styledKeyString + secondaryTextStyle.Render(" to change)")
@@ -41,53 +48,140 @@ func drawRadioButtons[T radioButtonItem](options []T, selected T, label string,
 }
 
 func drawGrid(m model, selectedPoints []vector2d) string {
-	var stringBuilder strings.Builder
-	for y, row := range m.grid {
-		for x, symbol := range row {
-			point := vector2d{x: x, y: y}
-
-			var formattedSymbol string
-			if slices.Contains(selectedPoints, point) {
-				formattedSymbol = m.symbolSet.formatSymbolHighlighted(symbol)
-			} else {
-				formattedSymbol = m.symbolSet.formatSymbol(symbol)
-			}
-
-			stringBuilder.WriteString(formattedSymbol)
-
-			if x != len(row)-1 {
-				stringBuilder.WriteString(" ")
-			}
-		}
+	return drawGridSprites(m, getGridSprites(m.grid, selectedPoints))
+}
 
-		if y != len(m.grid)-1 {
-			stringBuilder.WriteString("\n")
-		}
-	}
+// Draws the grid with symbols at any positions, including between grid points (e.g. during animations)
+func drawGridSprites(m model, sprites []sprite) string {
+	canvasString := drawGridCanvas(m, sprites)
 	border := lipgloss.RoundedBorder()
 	gridStyle := lipgloss.NewStyle().
-		BorderForeground(accentColor).
+		BorderForeground(m.theme.accent).
 		BorderStyle(border).
 		Padding(0, 1)
 
-	gridString := gridStyle.Render(stringBuilder.String())
+	gridString := gridStyle.Render(canvasString)
 
-	scoreString := fmt.Sprintf("Score: %s", humanize.Comma(int64(m.score)))
-	movesString := fmt.Sprintf("Moves: %s", humanize.Comma(int64(m.moveCount)))
+	if isCompactLayout(m) {
+		// Fit the score and moves on one line, to leave more room for text under the grid
+		hudStrings := make([]string, 0, 3)
+		for _, s := range drawHUD(m) {
+			if s != "" {
+				hudStrings = append(hudStrings, strings.TrimSpace(s))
+			}
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, gridString, strings.Join(hudStrings, "  "))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{gridString, ""}, drawHUD(m)...)...)
+}
 
+// Lines under the grid showing the score and moves (of each player, in hot-seat games), and any remaining hints
+func drawHUD(m model) []string {
 	var remainingMovesString string
-	if m.options.gameType == LimitedMoves {
+	if m.gameType.hasMoveLimit() {
 		remainingMoveCount := moveLimit - m.moveCount
 		remainingMovesString = fmt.Sprintf("Remaining moves: %d", remainingMoveCount)
 	} else {
 		remainingMovesString = ""
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, gridString, "", scoreString, movesString, remainingMovesString)
+	var hudStrings []string
+	if isHotSeatGame(m) {
+		if m.currentPlayer == noPlayer {
+			remainingMovesString = ""
+		}
+		hudStrings = append(drawPlayerScores(m), remainingMovesString)
+	} else {
+		scoreString := fmt.Sprintf("Score: %s", humanize.Comma(int64(m.score)))
+		movesString := fmt.Sprintf("Moves: %s", humanize.Comma(int64(m.moveCount)))
+		hudStrings = []string{scoreString, movesString, remainingMovesString}
+	}
+
+	// Only added if the number of hints is limited, so the HUD isn't taller than it needs to be
+	if remainingHintsString := drawRemainingHints(m); remainingHintsString != "" {
+		hudStrings = append(hudStrings, remainingHintsString)
+	}
+	return hudStrings
+}
+
+// Computes the screen position of the top-left symbol in the grid
+// This relies on grid views filling the full window width (see `drawGridLayout`), so the main view isn't shifted by
+// the centring in `model.View`
+func getGridOrigin(m model) vector2d {
+	const gridBorderWidth = 1
+	const gridPaddingWidth = 1
+	padding := getMainViewPadding(m)
+	origin := vector2d{
+		x: padding.x + gridBorderWidth + gridPaddingWidth,
+		y: padding.y + gridBorderWidth,
+	}
+	if !isCompactLayout(m) {
+		origin.y += lipgloss.Height(drawTitleBar(m))
+	}
+	return origin
+}
+
+// Converts a screen position (e.g. from a mouse event) to the point in the grid drawn at that position, if any
+func getGridPointAtScreenPosition(m model, screenPosition vector2d) (vector2d, bool) {
+	cellScale := getCellScale(m)
+	cellSize := getGridCellSize(m, cellScale)
+	clickableWidth := cellSize.x
+	if cellScale == 1 && !m.options.accessible {
+		clickableWidth-- // Not including the space between symbols
+	}
+	origin := getGridOrigin(m)
+	offset := vector2d{
+		x: screenPosition.x - origin.x,
+		y: screenPosition.y - origin.y,
+	}
+	if offset.x < 0 || offset.y < 0 || offset.x%cellSize.x >= clickableWidth {
+		return emptyVector2d, false
+	}
+
+	point := vector2d{
+		x: offset.x / cellSize.x,
+		y: offset.y / cellSize.y,
+	}
+	return point, m.grid.contains(point)
+}
+
+// Width available for text next to (or, in the compact layout, under) the grid
+func getGridLayoutTextWidth(m model, gridText string) int {
+	if isCompactLayout(m) {
+		return getMainViewWidth(m)
+	}
+	return getMainViewWidth(m) - lipgloss.Width(gridText) - 3
 }
 
 func drawGridLayout(m model, gridText string, text string) string {
-	textStyle := lipgloss.NewStyle().Width(m.windowSize.x - lipgloss.Width(gridText) - 8).PaddingLeft(3)
+	if isHotSeatGame(m) && m.currentPlayer != noPlayer {
+		text = lipgloss.JoinVertical(lipgloss.Left, drawTurnIndicator(m), "", text)
+	}
+	if isVersusGame(m) {
+		text = lipgloss.JoinVertical(lipgloss.Left, m.theme.secondaryTextStyle().Render(drawOpponentProgress(m)), "",
+			text)
+	}
+	if m.options.accessible && len(m.announcements) != 0 {
+		text = lipgloss.JoinVertical(lipgloss.Left, text, "", "Recent events:", strings.Join(m.announcements, "\n"))
+	}
+
+	// The move history is shown under the rest of the text, in the space that's left
+	textWidth := getGridLayoutTextWidth(m, gridText)
+	moveHistoryHeight := getMainViewHeight(m) - lipgloss.Height(lipgloss.NewStyle().Width(textWidth).Render(text)) - 1
+	if isCompactLayout(m) {
+		moveHistoryHeight -= lipgloss.Height(gridText)
+	}
+	if moveHistory := drawMoveHistory(m, textWidth, moveHistoryHeight); moveHistory != "" {
+		text = lipgloss.JoinVertical(lipgloss.Left, text, "", moveHistory)
+	}
+
+	if isCompactLayout(m) {
+		textStyle := lipgloss.NewStyle().Width(getMainViewWidth(m))
+		return lipgloss.JoinVertical(lipgloss.Left, gridText, textStyle.Render(text))
+	}
+
+	textStyle := lipgloss.NewStyle().Width(getMainViewWidth(m) - lipgloss.Width(gridText)).PaddingLeft(3)
 
 	return lipgloss.JoinHorizontal(
 		lipgloss.Top,
@@ -98,7 +192,7 @@ func drawGridLayout(m model, gridText string, text string) string {
 
 func drawTitleBar(m model) string {
 	horizontalPadding := 2
-	titleBarStyle := lipgloss.NewStyle().Background(whiteColor).Foreground(blackColor).Bold(true).Padding(0, horizontalPadding)
+	titleBarStyle := m.theme.highlightedStyle().Padding(0, horizontalPadding)
 	leftText := strings.Repeat(" ", lipgloss.Width(version))
 	centerText := lipgloss.PlaceHorizontal(m.windowSize.x-(2*lipgloss.Width(version))-(horizontalPadding*2), lipgloss.Center, "MATCH THREE GAME")
 	return titleBarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, leftText, centerText, version))
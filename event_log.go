@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"os"
+	"time"
+)
+
+// Details of an event in the event log, which are encoded as JSON
+type eventLogFields map[string]any
+
+// Opens the event log for appending, so events from several games can be kept in the same file
+func openEventLog(path string) (io.WriteCloser, error) {
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+}
+
+// Writes the event to the event log as a JSON object on its own line, along with its name and the current time
+func writeEventLogEntry(w io.Writer, e gameEvent) {
+	name, fields := e.logEntry()
+	entry := make(eventLogFields, len(fields)+2)
+	maps.Copy(entry, fields)
+	entry["time"] = time.Now().Format(time.RFC3339Nano)
+	entry["event"] = name
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// Ignore errors, as the log isn't essential to playing the game
+	_, _ = w.Write(append(data, '\n'))
+}
+
+// Sent when a game starts, with everything needed to replay it
+type gameStartEvent struct {
+	gameType     gameType
+	seed         int64
+	grid         grid
+	symbolCount  int
+	symbolSet    string
+	hintPolicy   hintPolicy
+	inputMode    inputMode
+	confirmMoves bool
+	playerCount  int
+	hotSeatBoard hotSeatBoard
+	versus       bool
+	dailyDate    string
+}
+
+func newGameStartEvent(m model) gameStartEvent {
+	e := gameStartEvent{
+		gameType:     m.gameType,
+		seed:         m.gameSeed,
+		grid:         m.grid,
+		symbolCount:  m.symbolCount,
+		symbolSet:    m.symbolSet.String(),
+		hintPolicy:   m.hintPolicy,
+		inputMode:    m.options.inputMode,
+		confirmMoves: m.options.confirmMoves,
+		playerCount:  maxInt(len(m.players), 1),
+		hotSeatBoard: m.hotSeatBoard,
+		versus:       isVersusGame(m),
+	}
+	if m.gameType == Daily {
+		e.dailyDate = m.dailyDate
+	}
+	return e
+}
+
+func (e gameStartEvent) announcement(m model) string {
+	return "" // The game screen shows that the game has started
+}
+
+func (e gameStartEvent) logEntry() (string, eventLogFields) {
+	fields := eventLogFields{
+		"gameType":     e.gameType.String(),
+		"seed":         e.seed,
+		"boardSize":    e.grid.size(),
+		"grid":         e.grid,
+		"symbolCount":  e.symbolCount,
+		"symbolSet":    e.symbolSet,
+		"hintPolicy":   e.hintPolicy.String(),
+		"inputMode":    e.inputMode.String(),
+		"confirmMoves": e.confirmMoves,
+		"playerCount":  e.playerCount,
+		"versus":       e.versus,
+	}
+	if e.playerCount > 1 {
+		fields["hotSeatBoard"] = e.hotSeatBoard.String()
+	}
+	if e.dailyDate != "" {
+		fields["dailyDate"] = e.dailyDate
+	}
+	return "game-start", fields
+}
+
+// Sent each time the grid is checked for matches after a move, before the matches are cleared
+type cascadeStepEvent struct {
+	depth      int
+	matchCount int
+	grid       grid
+}
+
+func (e cascadeStepEvent) announcement(m model) string {
+	return "" // Each match is announced instead
+}
+
+func (e cascadeStepEvent) logEntry() (string, eventLogFields) {
+	return "cascade-step", eventLogFields{"depth": e.depth, "matchCount": e.matchCount, "grid": e.grid}
+}
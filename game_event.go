@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"github.com/dustin/go-humanize"
+	"io"
+	"os"
+	"time"
+)
+
+// Something that happened during a game, e.g. a swap or a match
+type gameEvent interface {
+	// Describes the event in words, so it doesn't rely on colour or highlighting; empty if the event isn't announced
+	announcement(m model) string
+	// Returns the name and details of the event for the event log
+	logEntry() (string, eventLogFields)
+}
+
+type swapEvent struct {
+	point1, point2   vector2d
+	symbol1, symbol2 int
+	valid            bool
+}
+
+func (e swapEvent) announcement(m model) string {
+	if !e.valid {
+		return fmt.Sprintf("Not swapped %s at %s with %s at %s, as it would not result in a match.",
+			m.symbolSet.getSymbolString(e.symbol1), formatPoint(e.point1), m.symbolSet.getSymbolString(e.symbol2),
+			formatPoint(e.point2))
+	}
+
+	return fmt.Sprintf("Swapped %s at %s with %s at %s.", m.symbolSet.getSymbolString(e.symbol1),
+		formatPoint(e.point1), m.symbolSet.getSymbolString(e.symbol2), formatPoint(e.point2))
+}
+
+func (e swapEvent) logEntry() (string, eventLogFields) {
+	return "swap", eventLogFields{
+		"point1":  e.point1,
+		"point2":  e.point2,
+		"symbol1": e.symbol1,
+		"symbol2": e.symbol2,
+		"valid":   e.valid,
+	}
+}
+
+type matchEvent struct {
+	match        []vector2d
+	symbol       int
+	cascadeDepth int // 1 for the player's own match, 2 for matches formed after the grid is refreshed, and so on
+}
+
+func (e matchEvent) announcement(m model) string {
+	text := fmt.Sprintf("Matched %d %s.", len(e.match), m.symbolSet.getSymbolString(e.symbol))
+	if e.cascadeDepth > 1 {
+		text = fmt.Sprintf("Cascade %d: %s", e.cascadeDepth, text)
+	}
+	return text
+}
+
+func (e matchEvent) logEntry() (string, eventLogFields) {
+	return "match", eventLogFields{"cells": e.match, "symbol": e.symbol, "cascadeDepth": e.cascadeDepth}
+}
+
+type scoreEvent struct {
+	points int
+	score  int
+	scored bool // False if no points were scored as a hint was shown
+}
+
+func (e scoreEvent) announcement(m model) string {
+	if !e.scored {
+		return "No points since hint was shown."
+	}
+	if e.points < 0 {
+		return fmt.Sprintf("%s points for the hint (score: %s).", humanize.Comma(int64(e.points)),
+			humanize.Comma(int64(e.score)))
+	}
+
+	return fmt.Sprintf("+%s points (score: %s).", humanize.Comma(int64(e.points)), humanize.Comma(int64(e.score)))
+}
+
+func (e scoreEvent) logEntry() (string, eventLogFields) {
+	return "score", eventLogFields{"points": e.points, "score": e.score, "scored": e.scored}
+}
+
+type hintEvent struct {
+	potentialMatch []vector2d
+}
+
+func (e hintEvent) announcement(m model) string {
+	return "" // The hint is shown on the grid
+}
+
+func (e hintEvent) logEntry() (string, eventLogFields) {
+	return "hint", eventLogFields{"cells": e.potentialMatch}
+}
+
+// The match preview was turned on while selecting point 2, so the move doesn't score any points
+type previewEvent struct {
+	point1, point2 vector2d
+}
+
+func (e previewEvent) announcement(m model) string {
+	return "" // The preview is described in the text under the grid
+}
+
+func (e previewEvent) logEntry() (string, eventLogFields) {
+	return "preview", eventLogFields{"point1": e.point1, "point2": e.point2}
+}
+
+type gameOverEvent struct {
+	gameType     gameType
+	score        int
+	moveCount    int
+	hintsUsed    int
+	playerScores []int // Only set in hot-seat games, where the other fields aren't used
+}
+
+func (e gameOverEvent) announcement(m model) string {
+	if e.playerScores != nil {
+		return "Game over." // The standings are shown on the game over screen
+	}
+	return fmt.Sprintf("Game over. Final score: %s.", humanize.Comma(int64(e.score)))
+}
+
+func (e gameOverEvent) logEntry() (string, eventLogFields) {
+	if e.playerScores != nil {
+		return "game-over", eventLogFields{"gameType": e.gameType.String(), "playerScores": e.playerScores}
+	}
+	return "game-over", eventLogFields{
+		"gameType":  e.gameType.String(),
+		"score":     e.score,
+		"moveCount": e.moveCount,
+		"hintsUsed": e.hintsUsed,
+	}
+}
+
+const maxAnnouncementCount = 4
+
+// Records an event that happened during the game, writing it to the event log if there is one
+// In accessibility mode, the event is announced in the UI; it's also written to the announcement log, if there is one
+func emitGameEvent(m model, e gameEvent) model {
+	// Stats and achievements are for a single player, so aren't recorded in hot-seat games
+	if !isHotSeatGame(m) {
+		m.gameStats = recordGameEvent(m.gameStats, e)
+		m = updateAchievements(m, e)
+	}
+
+	if m.eventLog != nil {
+		writeEventLogEntry(m.eventLog, e)
+	}
+
+	if !m.options.accessible && m.announcementLog == nil {
+		return m
+	}
+
+	text := e.announcement(m)
+	if text == "" {
+		return m
+	}
+
+	if m.options.accessible {
+		// Copy rather than append to avoid modifying the announcements of previous copies of the model
+		announcements := make([]string, 0, maxAnnouncementCount)
+		if len(m.announcements) >= maxAnnouncementCount {
+			announcements = append(announcements, m.announcements[len(m.announcements)-maxAnnouncementCount+1:]...)
+		} else {
+			announcements = append(announcements, m.announcements...)
+		}
+		m.announcements = append(announcements, text)
+	}
+
+	if m.announcementLog != nil {
+		// Ignore errors, as the log isn't essential to playing the game
+		_, _ = fmt.Fprintf(m.announcementLog, "%s %s\n", time.Now().Format(time.TimeOnly), text)
+	}
+
+	return m
+}
+
+func formatPoint(p vector2d) string {
+	return fmt.Sprintf("(%d, %d)", p.x, p.y)
+}
+
+// Opens the announcement log for appending, so a screen reader (or `tail -f`) can follow it
+func openAnnouncementLog(path string) (io.WriteCloser, error) {
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+}
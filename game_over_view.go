@@ -7,48 +7,98 @@ import (
 )
 
 func showGameOverView(m model, text string) (tea.Model, tea.Cmd) {
-	m.view = gameOverView{text: text}
+	// Hot-seat games aren't recorded, as the stats belong to one player and there's no telling which of the players
+	// (if any) they belong to
+	if isHotSeatGame(m) {
+		m = endHotSeatGame(m)
+		playerScores := make([]int, 0, len(m.players))
+		for _, p := range m.players {
+			playerScores = append(playerScores, p.score)
+		}
+		m = emitGameEvent(m, gameOverEvent{gameType: m.gameType, playerScores: playerScores})
+		m.view = gameOverView{text: text + "\n\n" + drawStandings(m)}
+		m.help.ShowAll = false
+
+		return m, nil
+	}
+
+	if isVersusGame(m) {
+		m = finishVersusGame(m)
+	}
+
+	m = emitGameEvent(m, gameOverEvent{
+		gameType:  m.gameType,
+		score:     m.score,
+		moveCount: m.moveCount,
+		hintsUsed: m.gameStats.HintsUsed,
+	})
+	gameStats := m.gameStats // Reset once the game is recorded
+	if gameStats.HintsUsed > 0 {
+		text += "\n\n" + drawHintsUsed(m, gameStats)
+	}
+	m, cmd := recordGame(m)
+	if m.gameType == Daily {
+		text += "\n\n" + drawDailySummary(m, gameStats)
+	}
+	m.view = gameOverView{text: text, summary: drawShareSummary(m, gameStats)}
 	m.help.ShowAll = false
 
-	return m, nil
+	return m, cmd
 }
 
 type gameOverViewKeyMap struct {
-	TitleView key.Binding
-	Quit      key.Binding
+	TitleView    key.Binding
+	Stats        key.Binding
+	Achievements key.Binding
+	Share        key.Binding
+	Quit         key.Binding
 }
 
-var gameOverViewKeys = gameOverViewKeyMap{
-	TitleView: key.NewBinding(
-		key.WithKeys("t"),
-		key.WithHelp("t", "title screen"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("↵", "quit"),
-	),
+func newGameOverViewKeys(m model, g gameOverView) gameOverViewKeyMap {
+	keys := gameOverViewKeyMap{
+		TitleView:    newKeyBinding(m, gameOverTitleScreenAction, "title screen"),
+		Stats:        newKeyBinding(m, gameOverStatsAction, "statistics"),
+		Achievements: newKeyBinding(m, gameOverAchievementsAction, "achievements"),
+		Share:        newKeyBinding(m, gameOverShareAction, "share"),
+		Quit:         newKeyBinding(m, gameOverQuitAction, "quit"),
+	}
+	keys.Share.SetEnabled(g.summary != "")
+
+	return keys
 }
 
 func (s gameOverViewKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{s.TitleView, s.Quit}
+	return []key.Binding{s.TitleView, s.Stats, s.Achievements, s.Share, s.Quit}
 }
 
 func (s gameOverViewKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{s.TitleView, s.Quit},
+		{s.TitleView, s.Stats, s.Achievements, s.Share, s.Quit},
 	}
 }
 
 type gameOverView struct {
-	text string
+	text    string
+	summary string // Shared with the share key; empty if the game can't be shared (e.g. hot-seat games)
+	shared  bool
 }
 
 func (g gameOverView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	gameOverViewKeys := newGameOverViewKeys(m, g)
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, gameOverViewKeys.TitleView):
 			return showTitleView(m)
+		case key.Matches(msg, gameOverViewKeys.Stats):
+			return showStatsView(m)
+		case key.Matches(msg, gameOverViewKeys.Achievements):
+			return showAchievementsView(m)
+		case key.Matches(msg, gameOverViewKeys.Share):
+			g.shared = true
+			m.view = g
+			m.sharedSummary = g.summary
+			return m, copyToClipboard(m, g.summary)
 		case key.Matches(msg, gameOverViewKeys.Quit):
 			return m, tea.Quit
 		}
@@ -59,9 +109,16 @@ func (g gameOverView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 
 func (g gameOverView) draw(m model) string {
 	text := "Game over!\n\n" + g.text
+	if isVersusGame(m) {
+		// Drawn here rather than stored in the view, as the opponent may still be playing
+		text += "\n\n" + drawVersusResult(m)
+	}
+	if g.shared {
+		text += "\n\n" + drawSharedText(m)
+	}
 	gridText := drawGrid(m, []vector2d{})
-	m.help.Width = m.windowSize.x - lipgloss.Width(gridText) - 8 - 3
-	helpView := m.help.View(gameOverViewKeys)
+	m.help.Width = getGridLayoutTextWidth(m, gridText)
+	helpView := m.help.View(newGameOverViewKeys(m, g))
 	gameOverText := lipgloss.JoinVertical(lipgloss.Left, text, "", helpView)
 	gridLayoutText := drawGridLayout(m, gridText, gameOverText)
 
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"math"
+	"strings"
+)
+
+// Largest scale the grid is drawn at; each point is drawn as a tile with a border at scales above 1
+const maxCellScale = 3
+
+// Narrowest the text beside the grid can be before a smaller scale is used
+const minGridLayoutTextWidth = 30
+
+// Chooses the largest scale that fits in the window, so the grid isn't tiny on big terminals
+func getCellScale(m model) int {
+	if isCompactLayout(m) {
+		return 1
+	}
+
+	const gridBorderWidth = 1
+	const gridPaddingWidth = 1
+	hudHeight := 1 + len(drawHUD(m)) // Including the blank line above it
+	for scale := maxCellScale; scale > 1; scale-- {
+		canvasSize := getGridCanvasSize(m, scale)
+		requiredWindowSize := vector2d{
+			x: (2 * mainViewPadding.x) + canvasSize.x + 2*(gridBorderWidth+gridPaddingWidth) + 3 + minGridLayoutTextWidth,
+			y: lipgloss.Height(drawTitleBar(m)) + (2 * mainViewPadding.y) + canvasSize.y + (2 * gridBorderWidth) +
+				hudHeight,
+		}
+		if m.windowSize.x >= requiredWindowSize.x && m.windowSize.y >= requiredWindowSize.y {
+			return scale
+		}
+	}
+	return 1
+}
+
+func getSymbolWidth(m model) int {
+	return lipgloss.Width(m.symbolSet.formatSymbol(0, m.theme))
+}
+
+// Space around the symbol inside a tile, on each side
+// There's less vertical padding, since terminal characters are about twice as tall as they are wide
+func getTilePadding(scale int) vector2d {
+	return vector2d{x: 2*scale - 3, y: scale - 2}
+}
+
+// Size of each point in the grid, including the space between points
+func getGridCellSize(m model, scale int) vector2d {
+	symbolWidth := getSymbolWidth(m)
+	if scale == 1 {
+		if m.options.accessible {
+			return vector2d{x: symbolWidth + 2, y: 1} // Symbols are surrounded by markers (or spaces)
+		}
+		return vector2d{x: symbolWidth + 1, y: 1} // Symbols are separated by a space
+	}
+
+	const tileBorderWidth = 1
+	padding := getTilePadding(scale)
+	return vector2d{
+		x: symbolWidth + 2*(padding.x+tileBorderWidth),
+		y: 1 + 2*(padding.y+tileBorderWidth),
+	}
+}
+
+// Size of the grid's symbols, not including its border and padding
+func getGridCanvasSize(m model, scale int) vector2d {
+	cellSize := getGridCellSize(m, scale)
+	boardSize := getBoardSize(m)
+	size := vector2d{x: boardSize.x * cellSize.x, y: boardSize.y * cellSize.y}
+	if scale == 1 && !m.options.accessible {
+		size.x-- // No space after the last column
+	}
+	return size
+}
+
+// A character in the grid canvas; wide characters (e.g. emojis) are followed by continuation characters with no text
+type canvasCharacter struct {
+	text  string
+	width int
+}
+
+var blankCanvasCharacter = canvasCharacter{text: " ", width: 1}
+
+func newCanvasCharacters(text string) []canvasCharacter {
+	width := lipgloss.Width(text)
+	characters := make([]canvasCharacter, 0, width)
+	characters = append(characters, canvasCharacter{text: text, width: width})
+	for i := 1; i < width; i++ {
+		characters = append(characters, canvasCharacter{text: "", width: 0})
+	}
+	return characters
+}
+
+// Styled border characters for a tile, which are rendered once per frame rather than once per tile
+type tileBorder struct {
+	top, bottom, left, right                   string
+	topLeft, topRight, bottomLeft, bottomRight string
+}
+
+func newTileBorder(border lipgloss.Border, style lipgloss.Style) tileBorder {
+	return tileBorder{
+		top:         style.Render(border.Top),
+		bottom:      style.Render(border.Bottom),
+		left:        style.Render(border.Left),
+		right:       style.Render(border.Right),
+		topLeft:     style.Render(border.TopLeft),
+		topRight:    style.Render(border.TopRight),
+		bottomLeft:  style.Render(border.BottomLeft),
+		bottomRight: style.Render(border.BottomRight),
+	}
+}
+
+type gridCanvas struct {
+	characters [][]canvasCharacter
+	scale      int
+	cellSize   vector2d
+	// Selected tiles have a double border, so they're not only distinguished by colour
+	border, highlightedBorder tileBorder
+}
+
+func newGridCanvas(m model) gridCanvas {
+	scale := getCellScale(m)
+	size := getGridCanvasSize(m, scale)
+	characters := make([][]canvasCharacter, size.y)
+	for y := range characters {
+		characters[y] = make([]canvasCharacter, size.x)
+		for x := range characters[y] {
+			characters[y][x] = blankCanvasCharacter
+		}
+	}
+
+	return gridCanvas{
+		characters:        characters,
+		scale:             scale,
+		cellSize:          getGridCellSize(m, scale),
+		border:            newTileBorder(lipgloss.RoundedBorder(), m.theme.secondaryTextStyle()),
+		highlightedBorder: newTileBorder(lipgloss.DoubleBorder(), lipgloss.NewStyle().Foreground(m.theme.accent)),
+	}
+}
+
+// Draws the characters with their top-left corner at the given position
+// Characters outside the canvas are skipped, e.g. for symbols falling from above the grid
+func (c gridCanvas) draw(position vector2d, characters [][]canvasCharacter) {
+	for i, row := range characters {
+		y := position.y + i
+		if y < 0 || y >= len(c.characters) {
+			continue
+		}
+
+		for j, character := range row {
+			if character.width != 0 {
+				c.drawCharacter(position.x+j, c.characters[y], character)
+			}
+		}
+	}
+}
+
+func (c gridCanvas) drawCharacter(x int, row []canvasCharacter, character canvasCharacter) {
+	if x < 0 || x+character.width > len(row) {
+		return
+	}
+
+	// Clear any characters that would be partly covered, so wide characters don't get misaligned
+	for i := x; i < x+character.width; i++ {
+		start := i
+		for row[start].width == 0 {
+			start--
+		}
+		end := start + row[start].width
+		for j := start; j < end; j++ {
+			row[j] = blankCanvasCharacter
+		}
+	}
+
+	row[x] = character
+	for i := x + 1; i < x+character.width; i++ {
+		row[i] = canvasCharacter{text: "", width: 0}
+	}
+}
+
+func (c gridCanvas) String() string {
+	var stringBuilder strings.Builder
+	for y, row := range c.characters {
+		for _, character := range row {
+			stringBuilder.WriteString(character.text)
+		}
+
+		if y != len(c.characters)-1 {
+			stringBuilder.WriteString("\n")
+		}
+	}
+	return stringBuilder.String()
+}
+
+// Draws the symbols in the grid as a string, not including the grid's border
+func drawGridCanvas(m model, sprites []sprite) string {
+	c := newGridCanvas(m)
+	for _, s := range sprites {
+		position := vector2d{
+			x: int(math.Round(s.x * float64(c.cellSize.x))),
+			y: int(math.Round(s.y * float64(c.cellSize.y))),
+		}
+		if c.scale == 1 {
+			if m.options.accessible && !s.highlighted {
+				position.x++ // Leave space for the markers
+			}
+			c.draw(position, [][]canvasCharacter{drawSymbol(m, s)})
+		} else {
+			c.draw(position, c.drawTile(m, s))
+		}
+	}
+	return c.String()
+}
+
+func drawSymbol(m model, s sprite) []canvasCharacter {
+	if !s.highlighted {
+		return newCanvasCharacters(m.symbolSet.formatSymbol(s.symbol, m.theme))
+	}
+
+	symbol := newCanvasCharacters(m.symbolSet.formatSymbolHighlighted(s.symbol, m.theme))
+	if m.options.accessible {
+		// Surround selected symbols with brackets, so they're not only distinguished by colour
+		symbol = append(append(newCanvasCharacters("["), symbol...), newCanvasCharacters("]")...)
+	}
+	return symbol
+}
+
+// Draws the symbol surrounded by padding and a border
+func (c gridCanvas) drawTile(m model, s sprite) [][]canvasCharacter {
+	border := c.border
+	if s.highlighted {
+		border = c.highlightedBorder
+	}
+	innerWidth := c.cellSize.x - 2
+	symbolY := getTilePadding(c.scale).y
+
+	tile := make([][]canvasCharacter, 0, c.cellSize.y)
+	tile = append(tile, drawTileRow(border.topLeft, border.top, border.topRight, innerWidth))
+	for y := 0; y < c.cellSize.y-2; y++ {
+		if y != symbolY {
+			tile = append(tile, drawTileRow(border.left, " ", border.right, innerWidth))
+			continue
+		}
+
+		// The symbol (including any markers) is centred in the tile
+		symbol := drawSymbol(m, s)
+		sidePadding := (innerWidth - len(symbol)) / 2
+		row := newCanvasCharacters(border.left)
+		for i := 0; i < sidePadding; i++ {
+			row = append(row, blankCanvasCharacter)
+		}
+		row = append(row, symbol...)
+		for len(row) < innerWidth+1 {
+			row = append(row, blankCanvasCharacter)
+		}
+		tile = append(tile, append(row, newCanvasCharacters(border.right)...))
+	}
+	return append(tile, drawTileRow(border.bottomLeft, border.bottom, border.bottomRight, innerWidth))
+}
+
+func drawTileRow(left, middle, right string, innerWidth int) []canvasCharacter {
+	row := make([]canvasCharacter, 0, innerWidth+2)
+	row = append(row, newCanvasCharacters(left)...)
+	for i := 0; i < innerWidth; i++ {
+		row = append(row, newCanvasCharacters(middle)...)
+	}
+	return append(row, newCanvasCharacters(right)...)
+}
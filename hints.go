@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"strings"
+)
+
+const (
+	freeHintCount       = 3    // Hints per game with the limited hint policy
+	hintPointCost       = 100  // Points taken off the score for each hint with the point cost hint policy
+	hintPenaltyPercent  = 50   // Percentage of points lost for a move with a hint with the penalty hint policy
+	pointsPerEarnedHint = 1000 // Points needed to earn each hint with the earned hint policy
+	startingEarnedHints = 1
+)
+
+// Whether the number of hints in a game is limited, so the number remaining is shown
+func (hp hintPolicy) hasHintLimit() bool {
+	return hp == LimitedHintPolicy || hp == EarnedHintPolicy
+}
+
+// The hint policy to use in games where scores are compared with other players (i.e. daily challenges and versus
+// games), where only policies that make hints a disadvantage are allowed
+func (hp hintPolicy) forRankedGame() hintPolicy {
+	if hp == DisabledHintPolicy {
+		return DisabledHintPolicy
+	}
+	return NoPointsHintPolicy
+}
+
+// Describes what happens when a hint is shown, e.g. "100 points per hint"
+func (hp hintPolicy) describe() string {
+	switch hp {
+	case DisabledHintPolicy:
+		return "hints can't be shown"
+	case LimitedHintPolicy:
+		return english.Plural(freeHintCount, "free hint", "") + " per game"
+	case CostHintPolicy:
+		return humanize.Comma(hintPointCost) + " points per hint"
+	case PenaltyHintPolicy:
+		return fmt.Sprintf("the move scores %d%% fewer points", hintPenaltyPercent)
+	case EarnedHintPolicy:
+		return fmt.Sprintf("%s, plus 1 per %s points", english.Plural(startingEarnedHints, "free hint", ""),
+			humanize.Comma(pointsPerEarnedHint))
+	}
+	return "the move scores no points"
+}
+
+// Describes every hint policy, for the hint policy setting
+func describeHintPolicies() string {
+	descriptions := make([]string, 0, len(hintPolicies))
+	for _, hp := range hintPolicies {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s.", hp, hp.describe()))
+	}
+	return strings.Join(descriptions, " ")
+}
+
+// Returns the points a move's matches score, given their score without a hint
+func (hp hintPolicy) scoreMatches(points int, hintShown bool) int {
+	if !hintShown {
+		return points
+	}
+
+	switch hp {
+	case NoPointsHintPolicy:
+		return 0
+	case PenaltyHintPolicy:
+		return points * (100 - hintPenaltyPercent) / 100
+	}
+	return points
+}
+
+// Sets up the hints for a new game with the given hint policy
+func newGameHints(m model, hp hintPolicy) model {
+	m.hintPolicy = hp
+
+	switch m.hintPolicy {
+	case LimitedHintPolicy:
+		m.hintsRemaining = freeHintCount
+	case EarnedHintPolicy:
+		m.hintsRemaining = startingEarnedHints
+	default:
+		m.hintsRemaining = 0
+	}
+	return m
+}
+
+// Whether a hint (or the match preview) can be shown for the current move
+func isHintAvailable(m model) bool {
+	if m.hintPolicy == DisabledHintPolicy {
+		return false
+	}
+	// A hint that's already been used for this move can be shown again
+	return m.hintShown || !m.hintPolicy.hasHintLimit() || m.hintsRemaining > 0
+}
+
+// Uses a hint for the current move, applying the hint policy
+// The hint is only used once, even if it's shown again before the move
+func useHint(m model) model {
+	if m.hintShown {
+		return m
+	}
+
+	m.gameStats.HintsUsed++
+	m.hintShown = true
+	switch m.hintPolicy {
+	case LimitedHintPolicy, EarnedHintPolicy:
+		m.hintsRemaining--
+	case CostHintPolicy:
+		// The full cost is always taken, even if the score goes below zero
+		m.score -= hintPointCost
+		m = emitGameEvent(m, scoreEvent{points: -hintPointCost, score: m.score, scored: true})
+	}
+	return m
+}
+
+// Returns the points taken off the score for the hint shown for the current move, which are counted against the move
+func getHintPointCost(m model) int {
+	if m.hintShown && m.hintPolicy == CostHintPolicy {
+		return hintPointCost
+	}
+	return 0
+}
+
+// Adds any hints earned by the points just scored, with the earned hint policy
+func earnHints(m model, previousScore int) model {
+	if m.hintPolicy == EarnedHintPolicy && m.score > previousScore {
+		m.hintsRemaining += m.score/pointsPerEarnedHint - previousScore/pointsPerEarnedHint
+	}
+	return m
+}
+
+// Explains how the hint affects the score of the current move, or an empty string if it doesn't
+func drawHintScoreText(m model) string {
+	if !m.hintShown {
+		return ""
+	}
+
+	switch m.hintPolicy {
+	case NoPointsHintPolicy:
+		return "No points for this move since a hint was shown."
+	case PenaltyHintPolicy:
+		return fmt.Sprintf("%d%% fewer points for this move since a hint was shown.", hintPenaltyPercent)
+	case CostHintPolicy:
+		return fmt.Sprintf("%s points off this move since a hint was shown.", humanize.Comma(hintPointCost))
+	}
+	return ""
+}
+
+// Describes how many hints were used in the game, e.g. "Hints used: 2 (penalty)"
+func drawHintsUsed(m model, gameStats stats) string {
+	if m.hintPolicy == DisabledHintPolicy {
+		return "Hints used: none (disabled)"
+	}
+	return fmt.Sprintf("Hints used: %d (%s)", gameStats.HintsUsed, strings.ToLower(m.hintPolicy.String()))
+}
+
+// Remaining hints shown under the grid, or an empty string if the number of hints isn't limited
+func drawRemainingHints(m model) string {
+	if !m.hintPolicy.hasHintLimit() {
+		return ""
+	}
+	return fmt.Sprintf("Hints: %d", m.hintsRemaining)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+const maxPlayerCount = 4
+
+var playerCounts = []int{1, 2, 3, maxPlayerCount}
+
+// Whether players in a hot-seat game take turns on the same board or each have their own
+type hotSeatBoard int
+
+const (
+	SharedBoard hotSeatBoard = iota
+	// Each player has their own board, which starts the same and is refilled with the same symbols for the same moves
+	SeparateBoards
+)
+
+func (hb hotSeatBoard) String() string {
+	return [...]string{"Shared", "Separate"}[hb]
+}
+
+var hotSeatBoards = []hotSeatBoard{SharedBoard, SeparateBoards}
+
+// A player in a hot-seat game
+// The current player's score, moves and (with separate boards) grid are kept in the model while it's their turn, so
+// the rest of the game doesn't need to know about other players
+type player struct {
+	name           string
+	score          int
+	moveCount      int
+	movePoints     []int
+	hintsRemaining int        // Only used by hint policies with a limited number of hints
+	grid           grid       // Only used with separate boards
+	rand           *rand.Rand // Only used with separate boards
+}
+
+const noPlayer = -1
+
+// Whether the current game has more than one player taking turns
+func isHotSeatGame(m model) bool {
+	return len(m.players) > 1
+}
+
+func newPlayers(m model, count int) []player {
+	players := make([]player, 0, count)
+	for i := 0; i < count; i++ {
+		p := player{name: fmt.Sprintf("Player %d", i+1), hintsRemaining: m.hintsRemaining}
+		if m.hotSeatBoard == SeparateBoards {
+			// Every board uses the same seed, so they all start the same
+			p.rand = rand.New(rand.NewSource(m.gameSeed))
+			p.grid = newGridWithMatchesRemoved(p.rand, m.grid.size(), m.symbolCount)
+			ensurePotentialMatch(&p.grid, p.rand, m.symbolCount)
+		}
+		players = append(players, p)
+	}
+	return players
+}
+
+// Returns the players, with the current player's state copied from the model
+func getPlayers(m model) []player {
+	players := slices.Clone(m.players)
+	if m.currentPlayer != noPlayer {
+		p := &players[m.currentPlayer]
+		p.score = m.score
+		p.moveCount = m.moveCount
+		p.movePoints = m.movePoints
+		p.hintsRemaining = m.hintsRemaining
+		if m.hotSeatBoard == SeparateBoards {
+			p.grid = m.grid
+			p.rand = m.rand
+		}
+	}
+	return players
+}
+
+// Moves the given player's state into the model, so it's their turn
+func startTurn(m model, playerIndex int) model {
+	m.players = getPlayers(m)
+	m.currentPlayer = playerIndex
+
+	p := m.players[playerIndex]
+	m.score = p.score
+	m.moveCount = p.moveCount
+	m.movePoints = p.movePoints
+	m.hintsRemaining = p.hintsRemaining
+	if m.hotSeatBoard == SeparateBoards {
+		m.grid = p.grid
+		m.rand = p.rand
+	}
+
+	return emitGameEvent(m, turnEvent{player: p.name})
+}
+
+func startNextTurn(m model) model {
+	return startTurn(m, (m.currentPlayer+1)%len(m.players))
+}
+
+// Stores the current player's state with the others, so the final standings can be shown
+func endHotSeatGame(m model) model {
+	m.players = getPlayers(m)
+	m.currentPlayer = noPlayer
+	return m
+}
+
+type turnEvent struct {
+	player string
+}
+
+func (e turnEvent) announcement(m model) string {
+	return fmt.Sprintf("%s's turn.", e.player)
+}
+
+func (e turnEvent) logEntry() (string, eventLogFields) {
+	return "turn", eventLogFields{"player": e.player}
+}
+
+func drawTurnIndicator(m model) string {
+	return m.theme.highlightedStyle().Padding(0, 1).Render(fmt.Sprintf("%s's turn",
+		m.players[m.currentPlayer].name))
+}
+
+// Lines showing each player's score and moves, with the current player marked
+func drawPlayerScores(m model) []string {
+	lines := make([]string, 0, len(m.players))
+	for i, p := range getPlayers(m) {
+		marker := "  "
+		if i == m.currentPlayer {
+			marker = "> "
+		}
+		if isCompactLayout(m) {
+			// Players are shown on one line in the compact layout, so only show their scores
+			lines = append(lines, fmt.Sprintf("%sP%d %s", marker, i+1, humanize.Comma(int64(p.score))))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s%s: %s (%s)", marker, p.name, humanize.Comma(int64(p.score)),
+			english.Plural(p.moveCount, "move", "")))
+	}
+	return lines
+}
+
+// Lists the players from highest to lowest score, followed by the winner (or the players who tied for first place)
+func drawStandings(m model) string {
+	players := getPlayers(m)
+	slices.SortStableFunc(players, func(a, b player) int {
+		return b.score - a.score
+	})
+
+	var builder strings.Builder
+	builder.WriteString("Final standings:\n")
+	for i, p := range players {
+		builder.WriteString(fmt.Sprintf("%d. %s: %s\n", i+1, p.name, humanize.Comma(int64(p.score))))
+	}
+
+	winners := make([]string, 0, len(players))
+	for _, p := range players {
+		if p.score == players[0].score {
+			winners = append(winners, p.name)
+		}
+	}
+	builder.WriteString("\n")
+	if len(winners) == 1 {
+		builder.WriteString(fmt.Sprintf("%s wins!", winners[0]))
+	} else {
+		builder.WriteString(fmt.Sprintf("It's a tie between %s and %s!", strings.Join(winners[:len(winners)-1], ", "),
+			winners[len(winners)-1]))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	"slices"
+	"strings"
+)
+
+// Identifies something the player can do with a key, e.g. "game.up"
+// These are the names used to remap keys in the config file
+type keyAction string
+
+const (
+	titleStartAction            keyAction = "title.start"
+	titleQuitAction             keyAction = "title.quit"
+	titleToggleGameTypeAction   keyAction = "title.toggle-game-type"
+	titleToggleSymbolSetAction  keyAction = "title.toggle-symbol-set"
+	titleSettingsAction         keyAction = "title.settings"
+	titleStatsAction            keyAction = "title.stats"
+	titleAchievementsAction     keyAction = "title.achievements"
+	settingsUpAction            keyAction = "settings.up"
+	settingsDownAction          keyAction = "settings.down"
+	settingsNextValueAction     keyAction = "settings.next-value"
+	settingsPreviousValueAction keyAction = "settings.previous-value"
+	settingsResetAction         keyAction = "settings.reset"
+	settingsBackAction          keyAction = "settings.back"
+	gamePauseAction             keyAction = "game.pause"
+	gameHelpAction              keyAction = "game.help"
+	gameSelectAction            keyAction = "game.select"
+	gameCancelAction            keyAction = "game.cancel"
+	gameToggleHintAction        keyAction = "game.toggle-hint"
+	gameUpAction                keyAction = "game.up"
+	gameDownAction              keyAction = "game.down"
+	gameLeftAction              keyAction = "game.left"
+	gameRightAction             keyAction = "game.right"
+	gameSwapPrefixAction        keyAction = "game.swap-prefix"
+	gameSwapUpAction            keyAction = "game.swap-up"
+	gameSwapDownAction          keyAction = "game.swap-down"
+	gameSwapLeftAction          keyAction = "game.swap-left"
+	gameSwapRightAction         keyAction = "game.swap-right"
+	gameContinueAction          keyAction = "game.continue"
+	gameSkipAction              keyAction = "game.skip"
+	confirmationConfirmAction   keyAction = "confirmation.confirm"
+	confirmationCancelAction    keyAction = "confirmation.cancel"
+	gameOverTitleScreenAction   keyAction = "game-over.title-screen"
+	gameOverQuitAction          keyAction = "game-over.quit"
+	gameOverStatsAction         keyAction = "game-over.stats"
+	statsExportCSVAction        keyAction = "stats.export-csv"
+	statsExportJSONAction       keyAction = "stats.export-json"
+	statsBackAction             keyAction = "stats.back"
+	gameOverAchievementsAction  keyAction = "game-over.achievements"
+	achievementsBackAction      keyAction = "achievements.back"
+	versusLobbyCancelAction     keyAction = "versus-lobby.cancel"
+	versusLobbyQuitAction       keyAction = "versus-lobby.quit"
+	windowTooSmallQuitAction    keyAction = "window-too-small.quit"
+	configErrorContinueAction   keyAction = "config-error.continue"
+	gameOverShareAction         keyAction = "game-over.share"
+	pauseUpAction               keyAction = "pause.up"
+	pauseDownAction             keyAction = "pause.down"
+	pauseSelectAction           keyAction = "pause.select"
+	pauseResumeAction           keyAction = "pause.resume"
+	titleContinueAction         keyAction = "title.continue"
+	gameTogglePreviewAction     keyAction = "game.toggle-preview"
+	gameMoveHistoryAction       keyAction = "game.move-history"
+	gameMoveHistoryUpAction     keyAction = "game.move-history-up"
+	gameMoveHistoryDownAction   keyAction = "game.move-history-down"
+)
+
+// Maps each action to the keys that trigger it
+type keyBindings map[keyAction][]string
+
+func (kb keyBindings) clone() keyBindings {
+	clone := make(keyBindings, len(kb))
+	for action, keys := range kb {
+		clone[action] = slices.Clone(keys)
+	}
+	return clone
+}
+
+type keyProfile struct {
+	name     string
+	bindings keyBindings
+}
+
+func newDefaultKeyProfile() keyProfile {
+	return keyProfile{
+		name: "default",
+		bindings: keyBindings{
+			titleStartAction:            {"enter"},
+			titleQuitAction:             {"q"},
+			titleToggleGameTypeAction:   {"t"},
+			titleToggleSymbolSetAction:  {"s"},
+			titleSettingsAction:         {"o"}, // "o" for options
+			settingsUpAction:            {"up", "w"},
+			settingsDownAction:          {"down", "s"},
+			settingsNextValueAction:     {"right", "d", "enter"},
+			settingsPreviousValueAction: {"left", "a"},
+			settingsResetAction:         {"r"},
+			settingsBackAction:          {"esc"},
+			titleStatsAction:            {"i"}, // "i" for info
+			titleAchievementsAction:     {"a"},
+			gamePauseAction:             {"q"},
+			gameHelpAction:              {"?", "/"}, // Include "/" ("?" without pressing shift key) for convenience
+			gameSelectAction:            {"enter"},
+			gameCancelAction:            {"esc"},
+			gameToggleHintAction:        {"h"},
+			gameUpAction:                {"up", "w"},
+			gameDownAction:              {"down", "s"},
+			gameLeftAction:              {"left", "a"},
+			gameRightAction:             {"right", "d"},
+			gameSwapPrefixAction:        {" "},
+			gameSwapUpAction:            {"shift+up", "W"},
+			gameSwapDownAction:          {"shift+down", "S"},
+			gameSwapLeftAction:          {"shift+left", "A"},
+			gameSwapRightAction:         {"shift+right", "D"},
+			gameContinueAction:          {"enter"},
+			gameSkipAction:              {"enter"},
+			confirmationConfirmAction:   {"enter"},
+			confirmationCancelAction:    {"esc"},
+			gameOverTitleScreenAction:   {"t"},
+			gameOverQuitAction:          {"enter"},
+			gameOverStatsAction:         {"i"},
+			statsExportCSVAction:        {"c"},
+			statsExportJSONAction:       {"j"},
+			statsBackAction:             {"esc"},
+			gameOverAchievementsAction:  {"a"},
+			achievementsBackAction:      {"esc"},
+			versusLobbyCancelAction:     {"esc"},
+			versusLobbyQuitAction:       {"q"},
+			windowTooSmallQuitAction:    {"q"},
+			configErrorContinueAction:   {"enter"},
+			gameOverShareAction:         {"s"},
+			pauseUpAction:               {"up", "w"},
+			pauseDownAction:             {"down", "s"},
+			pauseSelectAction:           {"enter"},
+			pauseResumeAction:           {"esc", "q"},
+			titleContinueAction:         {"c"},
+			gameTogglePreviewAction:     {"p"},
+			gameMoveHistoryAction:       {"m"},
+			gameMoveHistoryUpAction:     {"["},
+			gameMoveHistoryDownAction:   {"]"},
+		},
+	}
+}
+
+func newVimKeyProfile() keyProfile {
+	bindings := newDefaultKeyProfile().bindings.clone()
+	bindings[gameUpAction] = []string{"up", "k"}
+	bindings[gameDownAction] = []string{"down", "j"}
+	bindings[gameLeftAction] = []string{"left", "h"}
+	bindings[gameRightAction] = []string{"right", "l"}
+	bindings[gameSwapUpAction] = []string{"shift+up", "K"}
+	bindings[gameSwapDownAction] = []string{"shift+down", "J"}
+	bindings[gameSwapLeftAction] = []string{"shift+left", "H"}
+	bindings[gameSwapRightAction] = []string{"shift+right", "L"}
+	bindings[gameToggleHintAction] = []string{"i"} // "h" is used for moving left
+	bindings[settingsUpAction] = []string{"up", "k"}
+	bindings[settingsDownAction] = []string{"down", "j"}
+	bindings[settingsNextValueAction] = []string{"right", "l", "enter"}
+	bindings[settingsPreviousValueAction] = []string{"left", "h"}
+	bindings[pauseUpAction] = []string{"up", "k"}
+	bindings[pauseDownAction] = []string{"down", "j"}
+
+	return keyProfile{name: "vim", bindings: bindings}
+}
+
+// Only uses keys on the left-hand side of the keyboard, so the right hand is free (e.g. for the mouse)
+func newLeftHandKeyProfile() keyProfile {
+	bindings := newDefaultKeyProfile().bindings.clone()
+	bindings[titleStartAction] = []string{"e"}
+	bindings[titleSettingsAction] = []string{"x"}
+	bindings[titleStatsAction] = []string{"c"}
+	bindings[settingsUpAction] = []string{"w"}
+	bindings[settingsDownAction] = []string{"s"}
+	bindings[settingsNextValueAction] = []string{"d", "e"}
+	bindings[settingsPreviousValueAction] = []string{"a"}
+	bindings[gameHelpAction] = []string{"tab"}
+	bindings[gameSelectAction] = []string{"e"}
+	bindings[gameToggleHintAction] = []string{"f"}
+	bindings[gameUpAction] = []string{"w"}
+	bindings[gameDownAction] = []string{"s"}
+	bindings[gameLeftAction] = []string{"a"}
+	bindings[gameRightAction] = []string{"d"}
+	bindings[gameSwapUpAction] = []string{"W"}
+	bindings[gameSwapDownAction] = []string{"S"}
+	bindings[gameSwapLeftAction] = []string{"A"}
+	bindings[gameSwapRightAction] = []string{"D"}
+	bindings[gameContinueAction] = []string{"e"}
+	bindings[gameSkipAction] = []string{"e"}
+	bindings[confirmationConfirmAction] = []string{"e"}
+	bindings[gameOverQuitAction] = []string{"e"}
+	bindings[gameOverStatsAction] = []string{"c"}
+	bindings[statsExportJSONAction] = []string{"f"}
+	bindings[configErrorContinueAction] = []string{"e"}
+	bindings[pauseUpAction] = []string{"w"}
+	bindings[pauseDownAction] = []string{"s"}
+	bindings[pauseSelectAction] = []string{"e"}
+	bindings[titleContinueAction] = []string{"r"} // "c" is used for statistics
+	bindings[gameTogglePreviewAction] = []string{"r"}
+	bindings[gameMoveHistoryAction] = []string{"x"}
+	bindings[gameMoveHistoryUpAction] = []string{"z"} // Either side of "x", which shows the move history
+	bindings[gameMoveHistoryDownAction] = []string{"c"}
+
+	return keyProfile{name: "left-hand", bindings: bindings}
+}
+
+var keyProfiles = []keyProfile{newDefaultKeyProfile(), newVimKeyProfile(), newLeftHandKeyProfile()}
+
+// Groups of actions which are available in the same view, so mustn't share any keys
+var keyBindingGroups = []struct {
+	view    string
+	actions []keyAction
+}{
+	{
+		view: "title view",
+		actions: []keyAction{titleStartAction, titleQuitAction, titleToggleGameTypeAction, titleToggleSymbolSetAction,
+			titleSettingsAction, titleStatsAction, titleAchievementsAction, titleContinueAction},
+	},
+	{
+		view: "settings view",
+		actions: []keyAction{settingsUpAction, settingsDownAction, settingsNextValueAction, settingsPreviousValueAction,
+			settingsResetAction, settingsBackAction},
+	},
+	{
+		view: "select first point view",
+		actions: []keyAction{gamePauseAction, gameHelpAction, gameSelectAction, gameToggleHintAction, gameUpAction,
+			gameDownAction, gameLeftAction, gameRightAction, gameSwapPrefixAction, gameSwapUpAction, gameSwapDownAction,
+			gameSwapLeftAction, gameSwapRightAction, gameMoveHistoryAction, gameMoveHistoryUpAction,
+			gameMoveHistoryDownAction},
+	},
+	{
+		view: "select second point view",
+		actions: []keyAction{gamePauseAction, gameHelpAction, gameSelectAction, gameCancelAction, gameUpAction,
+			gameDownAction, gameLeftAction, gameRightAction, gameTogglePreviewAction},
+	},
+	{
+		view:    "select point confirmation view",
+		actions: []keyAction{gamePauseAction, gameContinueAction},
+	},
+	{
+		view:    "swap animation view",
+		actions: []keyAction{gamePauseAction, gameSkipAction},
+	},
+	{
+		view:    "refresh grid view",
+		actions: []keyAction{gamePauseAction, gameSkipAction},
+	},
+	{
+		view:    "no possible moves view",
+		actions: []keyAction{gamePauseAction, gameContinueAction},
+	},
+	{
+		view:    "confirmation view",
+		actions: []keyAction{confirmationConfirmAction, confirmationCancelAction},
+	},
+	{
+		view: "game over view",
+		actions: []keyAction{gameOverTitleScreenAction, gameOverQuitAction, gameOverStatsAction,
+			gameOverAchievementsAction, gameOverShareAction},
+	},
+	{
+		view:    "stats view",
+		actions: []keyAction{statsExportCSVAction, statsExportJSONAction, statsBackAction},
+	},
+	{
+		view:    "achievements view",
+		actions: []keyAction{achievementsBackAction},
+	},
+	{
+		view:    "versus lobby view",
+		actions: []keyAction{versusLobbyCancelAction, versusLobbyQuitAction},
+	},
+	{
+		view:    "pause view",
+		actions: []keyAction{pauseUpAction, pauseDownAction, pauseSelectAction, pauseResumeAction},
+	},
+	// Views with a single action can't have conflicts, but are listed so every action is in a group
+	{
+		view:    "config error view",
+		actions: []keyAction{configErrorContinueAction},
+	},
+	{
+		view:    "window too small view",
+		actions: []keyAction{windowTooSmallQuitAction},
+	},
+}
+
+// Builds the key bindings from the chosen profile, with any remapped actions replacing the profile's keys
+func newKeyBindings(c keysConfig) (keyBindings, error) {
+	profileName := getKeyProfileName(c)
+	profileIndex := slices.IndexFunc(keyProfiles, func(p keyProfile) bool {
+		return p.name == profileName
+	})
+	if profileIndex == -1 {
+		return nil, fmt.Errorf("unknown key profile %q (available profiles: %s)", profileName,
+			strings.Join(getKeyProfileNames(), ", "))
+	}
+
+	bindings := keyProfiles[profileIndex].bindings.clone()
+	for action, keys := range c.Bindings {
+		if _, ok := bindings[keyAction(action)]; !ok {
+			return nil, fmt.Errorf("unknown key action %q", action)
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("no keys given for key action %q", action)
+		}
+
+		bindings[keyAction(action)] = keys
+	}
+
+	if err := checkKeyBindingConflicts(bindings); err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+func getKeyProfileName(c keysConfig) string {
+	if c.Profile == "" {
+		return newDefaultKeyProfile().name
+	}
+	return c.Profile
+}
+
+func getKeyProfileNames() []string {
+	names := make([]string, 0, len(keyProfiles))
+	for _, p := range keyProfiles {
+		names = append(names, p.name)
+	}
+	return names
+}
+
+func checkKeyBindingConflicts(bindings keyBindings) error {
+	conflicts := make([]string, 0)
+	for _, group := range keyBindingGroups {
+		actionsByKey := make(map[string]keyAction, len(group.actions))
+		for _, action := range group.actions {
+			for _, k := range bindings[action] {
+				if otherAction, present := actionsByKey[k]; present && otherAction != action {
+					conflicts = append(conflicts, fmt.Sprintf("%q is bound to both %q and %q in the %s",
+						formatKey(k), otherAction, action, group.view))
+					continue
+				}
+
+				actionsByKey[k] = action
+			}
+		}
+	}
+
+	if len(conflicts) != 0 {
+		return fmt.Errorf("conflicting key bindings:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+	return nil
+}
+
+var keyDisplayNames = map[string]string{
+	"up":          "↑",
+	"down":        "↓",
+	"left":        "←",
+	"right":       "→",
+	"shift+up":    "⇧↑",
+	"shift+down":  "⇧↓",
+	"shift+left":  "⇧←",
+	"shift+right": "⇧→",
+	"enter":       "↵",
+	" ":           "space",
+}
+
+func formatKey(k string) string {
+	if displayName, ok := keyDisplayNames[k]; ok {
+		return displayName
+	}
+	return k
+}
+
+func formatKeys(keys []string) string {
+	formattedKeys := make([]string, 0, len(keys))
+	for _, k := range keys {
+		formattedKeys = append(formattedKeys, formatKey(k))
+	}
+	return strings.Join(formattedKeys, "/")
+}
+
+func newKeyBinding(m model, action keyAction, description string) key.Binding {
+	keys := m.keys[action]
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(formatKeys(keys), description),
+	)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestKeyProfiles(t *testing.T) {
+	defaultBindings := newDefaultKeyProfile().bindings
+	for _, p := range keyProfiles {
+		t.Run(p.name, func(t *testing.T) {
+			if err := checkKeyBindingConflicts(p.bindings); err != nil {
+				t.Errorf("profile has conflicts: %v", err)
+			}
+			for action := range defaultBindings {
+				if len(p.bindings[action]) == 0 {
+					t.Errorf("no keys for %q", action)
+				}
+			}
+		})
+	}
+
+	// Conflicts are only found between actions in the same group
+	for action := range defaultBindings {
+		inGroup := false
+		for _, group := range keyBindingGroups {
+			inGroup = inGroup || slices.Contains(group.actions, action)
+		}
+		if !inGroup {
+			t.Errorf("%q isn't in any of the key binding groups", action)
+		}
+	}
+}
+
+func TestNewKeyBindings(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  keysConfig
+		action  keyAction
+		keys    []string
+		wantErr bool
+	}{
+		{
+			name:   "default profile",
+			action: gameUpAction,
+			keys:   newDefaultKeyProfile().bindings[gameUpAction],
+		},
+		{
+			name:   "vim profile",
+			config: keysConfig{Profile: "vim"},
+			action: gameUpAction,
+			keys:   newVimKeyProfile().bindings[gameUpAction],
+		},
+		{
+			name:   "remapped action",
+			config: keysConfig{Profile: "vim", Bindings: map[string][]string{"game.toggle-hint": {"t"}}},
+			action: gameToggleHintAction,
+			keys:   []string{"t"},
+		},
+		{
+			name:    "unknown profile",
+			config:  keysConfig{Profile: "emacs"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown action",
+			config:  keysConfig{Bindings: map[string][]string{"game.undo": {"u"}}},
+			wantErr: true,
+		},
+		{
+			name:    "no keys",
+			config:  keysConfig{Bindings: map[string][]string{"game.up": {}}},
+			wantErr: true,
+		},
+		{
+			name:    "conflict",
+			config:  keysConfig{Bindings: map[string][]string{"game.toggle-hint": {"up"}}},
+			wantErr: true,
+		},
+		{
+			name:   "same key in different views",
+			config: keysConfig{Bindings: map[string][]string{"game.toggle-hint": {"i"}}},
+			action: gameToggleHintAction,
+			keys:   []string{"i"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings, err := newKeyBindings(tt.config)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("no error, want one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(bindings[tt.action], tt.keys) {
+				t.Errorf("keys for %q are %q, want %q", tt.action, bindings[tt.action], tt.keys)
+			}
+		})
+	}
+}
+
+func TestApplyConfigWithConflictingKeys(t *testing.T) {
+	m := model{keys: newDefaultKeyProfile().bindings}
+	c := config{Keys: keysConfig{Bindings: map[string][]string{"game.toggle-hint": {"up"}}}}
+
+	m, err := applyConfig(m, c)
+	if err == nil {
+		t.Errorf("no error, want one for the conflict")
+	}
+	if !slices.Equal(m.keys[gameToggleHintAction], newDefaultKeyProfile().bindings[gameToggleHintAction]) {
+		t.Errorf("keys for %q are %q, want the default keys", gameToggleHintAction, m.keys[gameToggleHintAction])
+	}
+}
@@ -1,29 +1,67 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"io"
 	"math/rand"
 	"os"
+	"slices"
+	"strings"
 	"time"
 )
 
 var version = "dev"
 
-const symbolCount = 6
+// Number of symbols in each symbol set; games can use fewer symbols than this (see `options.symbolCount`)
+const maxSymbolCount = 6
 
-type grid [gridHeight][gridWidth]int
+// Each point holds the index of its symbol in the symbol set; rows come first, i.e. `g[y][x]`
+type grid [][]int
 
-func newGrid(r *rand.Rand) (g grid) {
-	for i := 0; i < gridHeight; i++ {
-		for j := 0; j < gridWidth; j++ {
+func newGrid(r *rand.Rand, size vector2d, symbolCount int) grid {
+	g := make(grid, size.y)
+	for i := range g {
+		g[i] = make([]int, size.x)
+		for j := range g[i] {
 			g[i][j] = r.Intn(symbolCount)
 		}
 	}
-	return
+	return g
+}
+
+func (g grid) width() int {
+	if len(g) == 0 {
+		return 0
+	}
+	return len(g[0])
+}
+
+func (g grid) height() int {
+	return len(g)
+}
+
+func (g grid) size() vector2d {
+	return vector2d{x: g.width(), y: g.height()}
+}
+
+// Grids share their points when copied, so must be cloned before being changed if the original is still used (e.g.
+// by an animation)
+func (g grid) clone() grid {
+	clone := make(grid, len(g))
+	for i, row := range g {
+		clone[i] = slices.Clone(row)
+	}
+	return clone
+}
+
+func (g grid) contains(p vector2d) bool {
+	return p.x >= 0 && p.x < g.width() && p.y >= 0 && p.y < g.height()
 }
 
 type vector2d struct {
@@ -32,91 +70,257 @@ type vector2d struct {
 
 var emptyVector2d = vector2d{x: -1, y: -1}
 
+// Points are encoded in JSON (e.g. in the event log) as e.g. `{"x":1,"y":2}`
+type jsonVector2d struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func (v vector2d) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonVector2d{X: v.x, Y: v.y})
+}
+
+func (v *vector2d) UnmarshalJSON(data []byte) error {
+	var jv jsonVector2d
+	if err := json.Unmarshal(data, &jv); err != nil {
+		return err
+	}
+	*v = vector2d{x: jv.X, y: jv.Y}
+	return nil
+}
+
 type gameType int
 
 const (
 	Endless gameType = iota
 	LimitedMoves
+	Daily // Limited moves, with the same board for everyone on the same day
 )
 
 func (gt gameType) String() string {
-	return [...]string{"Endless", "Limited moves"}[gt]
+	return [...]string{"Endless", "Limited moves", "Daily"}[gt]
+}
+
+func (gt gameType) hasMoveLimit() bool {
+	return gt == LimitedMoves || gt == Daily
+}
+
+type inputMode int
+
+const (
+	Classic inputMode = iota
+	Swipe
+)
+
+func (im inputMode) String() string {
+	return [...]string{"Classic", "Swipe"}[im]
+}
+
+type boardSize int
+
+const (
+	Small boardSize = iota
+	Medium
+	Large
+)
+
+func (bs boardSize) String() string {
+	return [...]string{"Small (8x8)", "Medium (10x10)", "Large (12x12)"}[bs]
+}
+
+var boardSizes = []boardSize{Small, Medium, Large}
+
+func (bs boardSize) dimensions() vector2d {
+	return [...]vector2d{{x: 8, y: 8}, {x: 10, y: 10}, {x: 12, y: 12}}[bs]
+}
+
+// What happens when the player shows a hint
+type hintPolicy int
+
+const (
+	NoPointsHintPolicy hintPolicy = iota // The move (including any cascades) scores no points
+	DisabledHintPolicy
+	LimitedHintPolicy // A few free hints per game (see `freeHintCount`), then no more
+	CostHintPolicy    // Each hint costs a fixed number of points (see `hintPointCost`)
+	PenaltyHintPolicy // The move (including any cascades) scores a percentage less (see `hintPenaltyPercent`)
+	EarnedHintPolicy  // Free hints are earned by scoring points (see `pointsPerEarnedHint`)
+)
+
+func (hp hintPolicy) String() string {
+	return [...]string{"No points", "Disabled", "Limited", "Point cost", "Penalty", "Earned"}[hp]
+}
+
+type onOffOption bool
+
+func (o onOffOption) String() string {
+	if o {
+		return "On"
+	}
+	return "Off"
 }
 
 type options struct {
-	gameType gameType
+	gameType       gameType
+	boardSize      boardSize
+	symbolCount    int // Fewer symbols make matches more likely, so the game is easier
+	hintPolicy     hintPolicy
+	inputMode      inputMode
+	confirmMoves   bool
+	accessible     bool // Whether to use markers rather than just colour, and announce game events in words
+	animationSpeed animationSpeed
+	reducedMotion  bool // Shortens animations to highlighting, e.g. matched symbols are highlighted rather than flashing
+	playerCount    int  // More than one player take turns in a hot-seat game
+	hotSeatBoard   hotSeatBoard
 }
 
-const gridHeight int = 10
-const gridWidth int = 10
 const minMatchLength int = 3
 const scorePerMatchedSymbol int = 40
 const moveLimit int = 20
 
 const emptySymbol int = -1
 
-var whiteColor = lipgloss.AdaptiveColor{
-	Light: "8",
-	Dark:  "7",
+type model struct {
+	rand       *rand.Rand // Used for the current game, so the game can be replayed from its seed
+	seedRand   *rand.Rand // Used to choose the seed of each game, except for daily challenges
+	gameSeed   int64
+	grid       grid
+	score      int
+	options    options
+	moveCount  int
+	movePoints []int      // Points scored by each move in the current game
+	moves      []gameMove // Each move in the current game, so it can be saved and replayed
+	// Game type and number of symbols used in the current game, which can differ from the options once it's started
+	gameType        gameType
+	symbolCount     int
+	view            view
+	previousView    view
+	point1          vector2d
+	point2          vector2d
+	help            help.Model
+	symbolSet       symbolSet
+	symbolSets      []symbolSet // Built-in symbol sets followed by any custom symbol sets from the config
+	theme           theme
+	themes          []theme // Built-in themes followed by any custom themes from the themes directory
+	windowSize      vector2d
+	hintShown       bool
+	hintPolicy      hintPolicy // Hint policy of the current game, which doesn't change if the setting is changed
+	hintsRemaining  int        // Only used by hint policies with a limited number of hints
+	previewShown    bool       // Whether the match preview is turned on while selecting point 2, which counts as a hint
+	cascadeDepth    int        // Number of times matches have been cleared during the current move
+	announcements   []string
+	announcementLog io.Writer
+	eventLog        io.Writer // Structured log of game events, e.g. for analysing games afterwards
+	keys            keyBindings
+	config          config
+	configReadOnly  bool // Whether changes shouldn't be written back to the config file
+	stats           stats
+	statsErr        error // Shown in the stats view if the stats couldn't be loaded or saved
+	statsReadOnly   bool  // Whether the stats couldn't be loaded, so shouldn't be written back to the stats file
+	gameStats       stats // Stats of the current game, which are added to `stats` when it ends
+	gameStartTime   time.Time
+	dailyDate       string   // Date of the current daily challenge
+	dailyRanked     bool     // Whether the current daily challenge is the first attempt that day
+	players         []player // Only used in hot-seat games
+	currentPlayer   int
+	hotSeatBoard    hotSeatBoard
+	versus          versusState
+	// Set in SSH sessions, where players are identified by their public key so they each have their own stats
+	userName string
+	userID   string
+	// Achievements unlocked by the current update, which are shown as toasts once it's finished
+	unlockedAchievements []achievement
+	toasts               []toast
+	// Where to write OSC 52 sequences, which copy text to the clipboard of the player's terminal, in SSH sessions (see
+	// `sessionWriter`)
+	clipboard     io.Writer
+	sharedSummary string     // Copied and printed once a local game exits
+	savedGame     *savedGame // Game saved from the pause menu, which can be continued from the title screen
+	savedGameErr  error      // Shown on the title screen if the saved game couldn't be loaded or continued
+	// Whether the move history is shown beside the grid, and the index in `getMoveHistory` of the move whose swapped
+	// cells are highlighted (or `noSelectedMove`)
+	moveHistoryShown    bool
+	moveHistorySelected int
+}
+
+// A valid swap made by the player
+type gameMove struct {
+	point1    vector2d
+	point2    vector2d
+	hintShown bool // Whether a hint was shown before the move, so it didn't score any points
+	// Details shown in the move history, which are filled in as the grid is refreshed after the move
+	symbol1, symbol2 int // Symbols at `point1` and `point2` before the swap
+	player           int // Player who made the move, in hot-seat games
+	matchCount       int // Including matches in cascades
+	cascadeDepth     int
+	points           int // Less the cost of the hint, with the point cost hint policy
 }
-var blackColor = lipgloss.AdaptiveColor{
-	Light: "15",
-	Dark:  "0",
+
+func newDefaultOptions() options {
+	return options{
+		gameType:       Endless,
+		boardSize:      Medium,
+		symbolCount:    maxSymbolCount,
+		hintPolicy:     NoPointsHintPolicy,
+		inputMode:      Classic,
+		confirmMoves:   true,
+		accessible:     false,
+		animationSpeed: Normal,
+		reducedMotion:  false,
+		playerCount:    1,
+		hotSeatBoard:   SharedBoard,
+	}
 }
 
-var accentColor = lipgloss.AdaptiveColor{
-	Light: "12",
-	Dark:  "4",
+// Size of the grid in the current game, or of the next game if one hasn't started yet
+func getBoardSize(m model) vector2d {
+	if m.grid != nil {
+		return m.grid.size()
+	}
+	return m.options.boardSize.dimensions()
 }
 
-var highlightedStyle = lipgloss.NewStyle().Background(whiteColor).Foreground(blackColor).Bold(true)
-var secondaryTextStyle = help.New().Styles.ShortDesc
+// Creates the initial model using the preferences from the config
+// If the config couldn't be loaded (`configErr`) or is invalid, the error is shown and the defaults are used instead
+func initialModel(r *rand.Rand, c config, configErr error) model {
+	m := model{
+		rand:          r,
+		seedRand:      r,
+		score:         0,
+		options:       newDefaultOptions(),
+		moveCount:     0,
+		view:          titleView{},
+		point1:        emptyVector2d,
+		point2:        emptyVector2d,
+		currentPlayer: noPlayer,
+		help:          help.New(),
+		symbolSet:     newEmojiSymbolSet(),
+		symbolSets:    slices.Clone(builtInSymbolSets),
+		themes:        slices.Clone(builtInThemes),
+		hintShown:     false,
+		keys:          newDefaultKeyProfile().bindings,
+		config:        c,
+	}
+	m = applyTheme(m, newDefaultTheme())
 
-type model struct {
-	rand         *rand.Rand
-	grid         grid
-	score        int
-	options      options
-	moveCount    int
-	view         view
-	previousView view
-	point1       vector2d
-	point2       vector2d
-	help         help.Model
-	symbolSet    symbolSet
-	windowSize   vector2d
-	hintShown    bool
-}
-
-func initialModel(r *rand.Rand) model {
-	return model{
-		rand:      r,
-		score:     0,
-		options:   options{gameType: Endless},
-		moveCount: 0,
-		view:      titleView{},
-		point1:    emptyVector2d,
-		point2:    emptyVector2d,
-		help:      help.New(),
-		symbolSet: newEmojiSymbolSet(),
-		hintShown: false,
-	}
-}
-
-type tickMsg time.Time
+	if configErr != nil {
+		m.configReadOnly = true
+	} else {
+		m, configErr = applyConfig(m, c)
+	}
+
+	if configErr != nil {
+		m.view = newConfigErrorView(m, configErr)
+		m.previousView = titleView{}
+	}
+
+	return m
+}
 
 // TODO: Add different game modes - e.g. endless, timed, limited number of moves
 // TODO: Check resizing
-// todo: change esc key to different key (?)
 
 func (m model) Init() tea.Cmd {
-	return nil
-}
-
-func tickCmd() tea.Cmd {
-	return tea.Tick(180*time.Millisecond, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
+	return connectVersus(m)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -132,27 +336,78 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else if isWindowLargeEnough(m) && m.view == (windowTooSmallView{}) {
 			return showPreviousView(m)
 		}
-	case tea.KeyMsg, tickMsg:
-		return m.view.update(msg, m)
+	case tea.KeyMsg, tea.MouseMsg, animationFrameMsg:
+		updatedModel, cmd := m.view.update(msg, m)
+		return showUnlockedAchievements(updatedModel.(model), cmd)
+	case versusListeningMsg, versusConnectedMsg, versusMessageMsg, versusErrorMsg, versusHeartbeatMsg:
+		updatedModel, cmd := updateVersus(m, msg)
+		return showUnlockedAchievements(updatedModel.(model), cmd)
+	case toastExpiredMsg:
+		m.toasts = removeToast(m.toasts, msg.id)
+	case configErrorMsg:
+		return showConfigErrorView(m, msg.err)
+	case statsErrorMsg:
+		m.statsErr = msg.err
 	}
 
 	return m, nil
 }
 
-var minWindowSize = vector2d{
+var mainViewPadding = vector2d{
+	x: 4,
+	y: 2,
+}
+
+// Smallest window with room for the title bar, padding and text beside the grid
+// Smaller windows use the compact layout instead
+var fullLayoutWindowSize = vector2d{
 	x: 80,
-	y: 22,
+	y: 24,
+}
+
+// Whether to drop the title bar and padding, and stack text under the grid rather than beside it
+func isCompactLayout(m model) bool {
+	return m.windowSize.x < fullLayoutWindowSize.x || m.windowSize.y < fullLayoutWindowSize.y
+}
+
+func getMainViewPadding(m model) vector2d {
+	if isCompactLayout(m) {
+		return vector2d{x: 0, y: 0}
+	}
+	return mainViewPadding
+}
+
+// Width available to views, i.e. not including the padding around them
+func getMainViewWidth(m model) int {
+	return m.windowSize.x - (2 * getMainViewPadding(m).x)
+}
+
+func getMainViewHeight(m model) int {
+	if isCompactLayout(m) {
+		return m.windowSize.y
+	}
+	return m.windowSize.y - lipgloss.Height(drawTitleBar(m)) - (2 * getMainViewPadding(m).y)
+}
+
+// The smallest window the game fits in (using the compact layout)
+func getMinWindowSize(m model) vector2d {
+	const gridBorderWidth = 1
+	const gridPaddingWidth = 1
+	const hudHeight = 1  // The compact layout fits the HUD on one line under the grid
+	const textHeight = 1 // At least one line of the prompt or help under the HUD
+	return vector2d{
+		x: getGridCanvasSize(m, 1).x + 2*(gridBorderWidth+gridPaddingWidth),
+		y: getGridCanvasSize(m, 1).y + 2*gridBorderWidth + hudHeight + textHeight,
+	}
 }
 
 func isWindowLargeEnough(m model) bool {
+	minWindowSize := getMinWindowSize(m)
 	return m.windowSize.x >= minWindowSize.x && m.windowSize.y >= minWindowSize.y
 }
 
-func newEndGameKeyBinding() key.Binding {
-	return key.NewBinding(
-		key.WithKeys("q"),
-		key.WithHelp("q", "end game"),
-	)
+func newPauseKeyBinding(m model) key.Binding {
+	return newKeyBinding(m, gamePauseAction, "pause")
 }
 
 func newHelpKeyBinding(m model) key.Binding {
@@ -163,24 +418,226 @@ func newHelpKeyBinding(m model) key.Binding {
 		helpKeyDescription = "show controls"
 	}
 
+	// Only show the first key, as any others are just for convenience (e.g. "/" is "?" without pressing shift)
+	keys := m.keys[gameHelpAction]
 	return key.NewBinding(
-		key.WithKeys("?", "/"), // Include "/" ("?" without pressing shift key) for convenience
-		key.WithHelp("?", helpKeyDescription),
+		key.WithKeys(keys...),
+		key.WithHelp(formatKey(keys[0]), helpKeyDescription),
 	)
 }
 
 func (m model) View() string {
-	titleBar := drawTitleBar(m)
+	padding := getMainViewPadding(m)
 	mainView := lipgloss.PlaceHorizontal(m.windowSize.x, lipgloss.Center,
-		lipgloss.NewStyle().Padding(2, 4).Render(m.view.draw(m)))
-	return lipgloss.NewStyle().Height(m.windowSize.y).Render(lipgloss.JoinVertical(lipgloss.Left, titleBar, mainView))
+		lipgloss.NewStyle().Padding(padding.y, padding.x).Render(m.view.draw(m)))
+	if !isCompactLayout(m) {
+		mainView = lipgloss.JoinVertical(lipgloss.Left, drawTitleBar(m), mainView)
+	}
+
+	// Cut off anything that doesn't fit in the window, otherwise the terminal would scroll and cut off the top instead
+	lines := strings.Split(mainView, "\n")
+	if len(lines) > m.windowSize.y {
+		lines = lines[:m.windowSize.y]
+	}
+	for len(lines) < m.windowSize.y {
+		lines = append(lines, "")
+	}
+	lines = drawToasts(m, lines)
+	return lipgloss.NewStyle().Height(m.windowSize.y).Render(strings.Join(lines, "\n"))
+}
+
+// Reports a config error on stderr, for modes that don't show the game in the terminal
+func reportConfigError(m model) {
+	if v, ok := m.view.(configErrorView); ok {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using the default options instead\n", v.err)
+	}
 }
 
 func main() {
+	os.Exit(run())
+}
+
+// Runs the game (or whatever else the flags ask for) and returns the exit code, so files opened along the way are
+// closed by their deferred calls before the process exits
+func run() int {
+	accessible := flag.Bool("accessible", false,
+		"turn on accessibility mode, which marks symbols without relying on colour and announces game events in words")
+	reducedMotion := flag.Bool("reduced-motion", false,
+		"turn on reduced motion, which replaces moving and flashing animations with highlighting")
+	announcementLogPath := flag.String("announcement-log", "",
+		"append announcements of game events to this `file`, e.g. for a screen reader to follow")
+	eventLogPath := flag.String("event-log", "",
+		"append each game event to this `file` as a JSON object on its own line, e.g. for analysing games afterwards")
+	exportStatsPath := flag.String("export-stats", "",
+		"export lifetime statistics to this `file` (as CSV if it ends in .csv, otherwise as JSON) and exit")
+	hostAddress := flag.String("host", "",
+		"host a versus game on this `address` (e.g. :7777) and wait for an opponent to join")
+	joinAddress := flag.String("join", "", "join a versus game hosted at this `address` (e.g. 192.168.1.2:7777)")
+	serveAddress := flag.String("serve", "",
+		"serve the game over SSH on this `address` (e.g. :23234), so players can connect with ssh instead")
+	sshHostKeyPath := flag.String("ssh-host-key", "",
+		"use the SSH host key in this `file` when serving, creating it if needed (default: next to the config file)")
+	bot := flag.Bool("bot", false,
+		"play a limited moves game with a bot program over stdin and stdout instead of in the terminal (see the README)")
+	botSeed := flag.Int64("bot-seed", 0, "use this `seed` for the bot game, so the same game can be replayed (default: random)")
+	botTimeout := flag.Duration("bot-timeout", defaultBotTimeout,
+		"end the bot game if the bot takes longer than this `duration` to reply to a move")
+	recordPath := flag.String("record", "",
+		"record the game to this `file` as an asciicast (v2), which can be played back with asciinema")
+	replayPath := flag.String("replay", "",
+		"render the last game in this event log `file` to the -record file instead of playing, then exit")
+	replaySize := flag.String("replay-size", fmt.Sprintf("%dx%d", defaultReplaySize.x, defaultReplaySize.y),
+		"render -replay recordings at this terminal `size`")
+	flag.Parse()
+
+	if *hostAddress != "" && *joinAddress != "" {
+		fmt.Fprintln(os.Stderr, "Error: -host and -join can't be used together")
+		return 2
+	}
+	if *botTimeout <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: -bot-timeout must be positive")
+		return 2
+	}
+	if *replayPath != "" && *recordPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -replay needs a file to -record to")
+		return 2
+	}
+	replayTerminalSize, err := parseTerminalSize(*replaySize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -replay-size: %v\n", err)
+		return 2
+	}
+
+	s, statsErr := loadStats("")
+	if *exportStatsPath != "" {
+		if statsErr == nil {
+			statsErr = exportStats(s, *exportStatsPath)
+		}
+		if statsErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not export statistics: %v\n", statsErr)
+			return 1
+		}
+		return 0
+	}
+
+	c, configErr := loadConfig()
+	if *serveAddress != "" {
+		if *sshHostKeyPath == "" {
+			path, err := getDefaultSSHHostKeyPath()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: could not find config directory: %v\n", err)
+				return 1
+			}
+			*sshHostKeyPath = path
+		}
+		if err := serveSSH(*serveAddress, *sshHostKeyPath, c, configErr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if *bot {
+		// Stdout is used for the protocol, so config errors can only be reported on stderr
+		m := initialModel(rand.New(rand.NewSource(time.Now().UnixNano())), c, configErr)
+		reportConfigError(m)
+		if *botSeed == 0 {
+			*botSeed = time.Now().UnixNano()
+		}
+		err := runBotGame(os.Stdin, os.Stdout, *botSeed, m.options.boardSize, m.options.symbolCount, *botTimeout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	p := tea.NewProgram(initialModel(r))
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error: %v", err)
-		os.Exit(1)
+	m := initialModel(r, c, configErr)
+	m.stats = s
+	m.statsErr = statsErr
+	m.statsReadOnly = statsErr != nil
+	m.savedGame, m.savedGameErr = loadSavedGame("")
+
+	if *accessible {
+		m.options.accessible = true
+	}
+	if *reducedMotion {
+		m.options.reducedMotion = true
+	}
+	if *replayPath != "" {
+		reportConfigError(m)
+		if err := replayToFile(*replayPath, *recordPath, replayTerminalSize, m); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not render recording: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if *hostAddress != "" || *joinAddress != "" {
+		role, address := HostVersusRole, *hostAddress
+		if *joinAddress != "" {
+			role, address = JoinVersusRole, *joinAddress
+		}
+		previousView := m.view
+		m = showVersusLobbyView(m, role, address)
+		if _, ok := previousView.(configErrorView); ok {
+			// Show the config error first, then the lobby once it's dismissed
+			m.previousView = m.view
+			m.view = previousView
+		}
+	}
+	if *announcementLogPath == "" {
+		*announcementLogPath = c.AnnouncementLog
 	}
+	if *announcementLogPath != "" {
+		announcementLog, err := openAnnouncementLog(*announcementLogPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not open announcement log: %v\n", err)
+			return 1
+		}
+		defer announcementLog.Close()
+
+		m.announcementLog = announcementLog
+	}
+	if *eventLogPath == "" {
+		*eventLogPath = c.EventLog
+	}
+	if *eventLogPath != "" {
+		eventLog, err := openEventLog(*eventLogPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not open event log: %v\n", err)
+			return 1
+		}
+		defer eventLog.Close()
+
+		m.eventLog = eventLog
+	}
+
+	var program tea.Model = m
+	if *recordPath != "" {
+		recording, err := os.Create(*recordPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not create recording: %v\n", err)
+			return 1
+		}
+		defer recording.Close()
+
+		program = recordingModel{model: m, recorder: newCastRecorder(recording, time.Now())}
+	}
+
+	p := tea.NewProgram(program, tea.WithMouseAllMotion()) // All motion events are needed for hover highlighting
+	finalModel, err := p.Run()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+	if r, ok := finalModel.(recordingModel); ok {
+		finalModel = r.model
+	}
+	if m, ok := finalModel.(model); ok && m.sharedSummary != "" {
+		// Only written now the program has stopped rendering, so it can't end up in the middle of a frame
+		// Ignore errors, as the summary is printed too, in case the terminal doesn't support OSC 52
+		_, _ = newClipboardSequence(m, m.sharedSummary).WriteTo(os.Stdout)
+		fmt.Println(m.sharedSummary)
+	}
+
+	return 0
 }
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"slices"
+	"strings"
+)
+
+// Number of moves listed in the move history, counting back from the latest move
+const moveHistoryLength = 50
+
+// Used for `model.moveHistorySelected` when none of the moves in the move history are selected
+const noSelectedMove = -1
+
+// Returns the moves listed in the move history, oldest first, and the number of the first one listed
+// With separate boards in a hot-seat game, only the current player's moves are listed, as the other players' moves
+// were made on other boards
+func getMoveHistory(m model) ([]gameMove, int) {
+	moves := m.moves
+	if isHotSeatGame(m) && m.hotSeatBoard == SeparateBoards {
+		moves = slices.DeleteFunc(slices.Clone(moves), func(move gameMove) bool {
+			return move.player != m.currentPlayer
+		})
+	}
+	start := maxInt(len(moves)-moveHistoryLength, 0)
+	return moves[start:], start + 1
+}
+
+// Toggles the move history beside the grid, with none of the moves selected
+func toggleMoveHistory(m model) model {
+	m.moveHistoryShown = !m.moveHistoryShown
+	m.moveHistorySelected = noSelectedMove
+	return m
+}
+
+// Selects the previous move in the move history, starting from the latest move if none are selected
+func selectPreviousMove(m model) model {
+	moves, _ := getMoveHistory(m)
+	if m.moveHistorySelected == noSelectedMove {
+		m.moveHistorySelected = len(moves) - 1
+	} else {
+		m.moveHistorySelected = maxInt(m.moveHistorySelected-1, 0)
+	}
+	return m
+}
+
+// Selects the next move in the move history, or none of them after the latest move (so it's back to just playing)
+func selectNextMove(m model) model {
+	moves, _ := getMoveHistory(m)
+	if m.moveHistorySelected == noSelectedMove || m.moveHistorySelected >= len(moves)-1 {
+		m.moveHistorySelected = noSelectedMove
+	} else {
+		m.moveHistorySelected++
+	}
+	return m
+}
+
+// Returns the cells swapped by the selected move, so they can be highlighted on the board
+func getSelectedMovePoints(m model) []vector2d {
+	moves, _ := getMoveHistory(m)
+	if !m.moveHistoryShown || m.moveHistorySelected == noSelectedMove || m.moveHistorySelected >= len(moves) {
+		return nil
+	}
+	move := moves[m.moveHistorySelected]
+	return []vector2d{move.point1, move.point2}
+}
+
+// Describes the move in one line, e.g. "3. 🍋 (3, 4) ↔ 🍇 (4, 4): 2 matches, cascade 2, +240"
+func drawMoveHistoryRow(m model, moveNumber int, move gameMove) string {
+	formatSymbol := func(symbol int) string {
+		if m.options.accessible {
+			return m.symbolSet.getSymbolString(symbol)
+		}
+		return m.symbolSet.formatSymbol(symbol, m.theme)
+	}
+
+	// With separate boards only the current player's moves are listed, so they don't need their name
+	var player string
+	if isHotSeatGame(m) && m.hotSeatBoard == SharedBoard && move.player != noPlayer {
+		player = m.players[move.player].name + ": "
+	}
+	points := humanize.Comma(int64(move.points))
+	if move.points >= 0 {
+		points = "+" + points
+	}
+	if move.hintShown {
+		points += " (hint)"
+	}
+	return fmt.Sprintf("%d. %s%s %s ↔ %s %s: %s, cascade %d, %s", moveNumber, player, formatSymbol(move.symbol1),
+		formatPoint(move.point1), formatSymbol(move.symbol2), formatPoint(move.point2),
+		english.Plural(move.matchCount, "match", ""), move.cascadeDepth, points)
+}
+
+// Draws the move history to fit in the given space, scrolled to the selected move (or the latest move), or returns
+// an empty string if it isn't shown or doesn't fit
+func drawMoveHistory(m model, width int, height int) string {
+	moves, firstMoveNumber := getMoveHistory(m)
+	if !m.moveHistoryShown || len(moves) == 0 {
+		return ""
+	}
+
+	rows := make([]string, 0, len(moves))
+	rowStyle := lipgloss.NewStyle().MaxWidth(width) // Cut off rather than wrapped, so each move is one row
+	for i, move := range moves {
+		row := drawMoveHistoryRow(m, firstMoveNumber+i, move)
+		if i == m.moveHistorySelected {
+			// The marker shows the selected move without relying on colour
+			rows = append(rows, rowStyle.Render(m.theme.highlightedStyle().Render("> "+row)))
+		} else {
+			rows = append(rows, rowStyle.Render("  "+row))
+		}
+	}
+
+	heading := "Move history"
+	if m.moveHistorySelected != noSelectedMove {
+		heading += " (swapped cells are highlighted)"
+	}
+	headingText := lipgloss.NewStyle().Bold(true).MaxWidth(width).Render(heading)
+
+	// Scrolling needs room for the arrows and at least one move
+	rowsHeight := height - lipgloss.Height(headingText)
+	if rowsHeight < minInt(len(rows), 3) {
+		return ""
+	}
+	scrolledRow := m.moveHistorySelected
+	if scrolledRow == noSelectedMove {
+		scrolledRow = len(rows) - 1
+	}
+	return strings.Join([]string{headingText, drawScrolledRows(m, rows, scrolledRow, rowsHeight)}, "\n")
+}
@@ -8,34 +8,31 @@ import (
 )
 
 func showNoPossibleMovesView(m model) (tea.Model, tea.Cmd) {
-	m.view = newNoPossibleMovesView()
+	m.view = newNoPossibleMovesView(m)
 	m.help.ShowAll = false
 
 	return m, nil
 }
 
 type noPossibleMovesViewKeyMap struct {
-	EndGame key.Binding
+	Pause   key.Binding
 	Confirm key.Binding
 }
 
-func newNoPossibleMovesViewKeys() noPossibleMovesViewKeyMap {
+func newNoPossibleMovesViewKeys(m model) noPossibleMovesViewKeyMap {
 	return noPossibleMovesViewKeyMap{
-		EndGame: newEndGameKeyBinding(),
-		Confirm: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("↵", "continue"),
-		),
+		Pause:   newPauseKeyBinding(m),
+		Confirm: newKeyBinding(m, gameContinueAction, "continue"),
 	}
 }
 
 func (s noPossibleMovesViewKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{s.Confirm, s.EndGame}
+	return []key.Binding{s.Confirm, s.Pause}
 }
 
 func (s noPossibleMovesViewKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{s.Confirm, s.EndGame},
+		{s.Confirm, s.Pause},
 	}
 }
 
@@ -43,9 +40,9 @@ type noPossibleMovesView struct {
 	keys noPossibleMovesViewKeyMap
 }
 
-func newNoPossibleMovesView() noPossibleMovesView {
+func newNoPossibleMovesView(m model) noPossibleMovesView {
 	return noPossibleMovesView{
-		keys: newNoPossibleMovesViewKeys(),
+		keys: newNoPossibleMovesViewKeys(m),
 	}
 }
 
@@ -53,10 +50,10 @@ func (n noPossibleMovesView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, n.keys.EndGame):
-			return showEndGameConfirmationView(m)
+		case key.Matches(msg, n.keys.Pause):
+			return showPauseView(m)
 		case key.Matches(msg, n.keys.Confirm):
-			ensurePotentialMatch(&m.grid, m.rand)
+			ensurePotentialMatch(&m.grid, m.rand, m.symbolCount)
 
 			return showSelectFirstPointView(m)
 		}
@@ -68,8 +65,8 @@ func (n noPossibleMovesView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 func (n noPossibleMovesView) draw(m model) string {
 	text := fmt.Sprintf("No more possible moves\n\nPress %s to generate a new grid...",
 		lipgloss.NewStyle().Bold(true).Render(n.keys.Confirm.Help().Key))
-	gridText := drawGrid(m, []vector2d{m.point1, m.point2})
-	m.help.Width = m.windowSize.x - lipgloss.Width(gridText) - 8 - 3
+	gridText := drawGrid(m, []vector2d{})
+	m.help.Width = getGridLayoutTextWidth(m, gridText)
 	helpView := m.help.View(n.keys)
 	noMorePossibleMovesText := lipgloss.JoinVertical(lipgloss.Left, text, "", helpView)
 	gridLayoutText := drawGridLayout(m, gridText, noMorePossibleMovesText)
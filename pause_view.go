@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+	"time"
+)
+
+// Pauses the game, freezing any animations and the play time until the game is resumed
+func showPauseView(m model) (tea.Model, tea.Cmd) {
+	m.help.ShowAll = false
+
+	p := pauseView{
+		keys:       newPauseViewKeys(m),
+		pausedView: m.view,
+		pausedAt:   time.Now(),
+	}
+	m.view = &p
+
+	return m, nil
+}
+
+type pauseViewKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Resume key.Binding
+}
+
+func newPauseViewKeys(m model) pauseViewKeyMap {
+	return pauseViewKeyMap{
+		Up:     newKeyBinding(m, pauseUpAction, "up"),
+		Down:   newKeyBinding(m, pauseDownAction, "down"),
+		Select: newKeyBinding(m, pauseSelectAction, "select"),
+		Resume: newKeyBinding(m, pauseResumeAction, "resume"),
+	}
+}
+
+func (k pauseViewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Select, k.Resume}
+}
+
+func (k pauseViewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Select, k.Resume},
+	}
+}
+
+type pauseMenuItem struct {
+	name        string
+	description string
+	// Returns why the item can't be chosen in the current game, or an empty string if it can
+	unavailableReason func(m model) string
+	choose            func(p *pauseView, m model) (tea.Model, tea.Cmd)
+}
+
+var pauseMenuItems = []pauseMenuItem{
+	{
+		name:        "Resume",
+		description: "Carry on playing.",
+		choose: func(p *pauseView, m model) (tea.Model, tea.Cmd) {
+			return p.resume(m)
+		},
+	},
+	{
+		name:              "Restart on the same board",
+		description:       "Start this game again from the beginning, with the same symbols.",
+		unavailableReason: getRestartUnavailableReason,
+		choose: func(p *pauseView, m model) (tea.Model, tea.Cmd) {
+			return restartGame(m, true)
+		},
+	},
+	{
+		name:        "Restart on a new board",
+		description: "Start a new game of the same type, with different symbols.",
+		unavailableReason: func(m model) string {
+			if m.gameType == Daily {
+				return "Daily challenges always use the same board."
+			}
+			return getRestartUnavailableReason(m)
+		},
+		choose: func(p *pauseView, m model) (tea.Model, tea.Cmd) {
+			return restartGame(m, false)
+		},
+	},
+	{
+		name:        "Settings",
+		description: "Change the settings; board size, symbol count and hint policy take effect from the next game.",
+		choose: func(p *pauseView, m model) (tea.Model, tea.Cmd) {
+			return showSettingsView(m)
+		},
+	},
+	{
+		name:        "How to play",
+		description: "The rules of the game.",
+		choose: func(p *pauseView, m model) (tea.Model, tea.Cmd) {
+			p.showRules = true
+			return m, nil
+		},
+	},
+	{
+		name:        "End game",
+		description: "End the game now and see your final score.",
+		choose: func(p *pauseView, m model) (tea.Model, tea.Cmd) {
+			return showEndGameConfirmationView(m)
+		},
+	},
+	{
+		name:              "Save and quit to title",
+		description:       "Save the game and go back to the title screen, where you can continue it later.",
+		unavailableReason: getSaveUnavailableReason,
+		choose: func(p *pauseView, m model) (tea.Model, tea.Cmd) {
+			updatedModel, cmd, err := saveGameAndQuit(m, p.pausedAt.Sub(m.gameStartTime))
+			p.err = err
+			return updatedModel, cmd
+		},
+	},
+	{
+		name:        "Quit",
+		description: "Quit the game without saving.",
+		choose: func(p *pauseView, m model) (tea.Model, tea.Cmd) {
+			return m, tea.Quit
+		},
+	},
+}
+
+func getRestartUnavailableReason(m model) string {
+	if isVersusGame(m) {
+		return "Versus games can't be restarted, as your opponent carries on playing."
+	}
+	return ""
+}
+
+const rulesText = "Swap two neighbouring symbols to line up three or more of the same symbol in a row or column. " +
+	"Matched symbols are cleared, and the symbols above fall down to fill the gaps, which can make more matches. " +
+	"Longer matches score more points, and every match in a cascade scores too. If no more moves are possible, a " +
+	"new grid is made.\n\n" +
+	"Endless games go on until you end them, while limited moves games and daily challenges end after %d moves.\n\n" +
+	"Hint policy in this game: %s (%s)."
+
+type pauseView struct {
+	keys       pauseViewKeyMap
+	selected   int  // Index of the selected menu item
+	pausedView view // Game view that's resumed when the menu is closed
+	pausedAt   time.Time
+	showRules  bool
+	err        error // Shown if the game couldn't be saved
+}
+
+func (p *pauseView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if p.showRules {
+			if key.Matches(msg, p.keys.Select, p.keys.Resume) {
+				p.showRules = false
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, p.keys.Resume):
+			return p.resume(m)
+		case key.Matches(msg, p.keys.Up):
+			p.selected = (p.selected - 1 + len(pauseMenuItems)) % len(pauseMenuItems) // Wrap around to the bottom
+		case key.Matches(msg, p.keys.Down):
+			p.selected = (p.selected + 1) % len(pauseMenuItems) // Wrap around to the top
+		case key.Matches(msg, p.keys.Select):
+			item := pauseMenuItems[p.selected]
+			if item.unavailableReason != nil && item.unavailableReason(m) != "" {
+				return m, nil
+			}
+			p.err = nil
+			return item.choose(p, m)
+		}
+	}
+
+	return m, nil
+}
+
+func (p *pauseView) resume(m model) (tea.Model, tea.Cmd) {
+	// Play time doesn't include the time spent paused
+	m.gameStartTime = m.gameStartTime.Add(time.Since(p.pausedAt))
+
+	// Settings (e.g. the key profile) may have changed while paused, so the keys need updating
+	if _, ok := p.pausedView.(*selectFirstPointView); ok {
+		return returnToSelectFirstPointView(m)
+	}
+	m.previousView = p.pausedView
+	return showPreviousView(m)
+}
+
+func (p *pauseView) draw(m model) string {
+	var text string
+	if p.showRules {
+		text = fmt.Sprintf(rulesText, moveLimit, strings.ToLower(m.hintPolicy.String()), m.hintPolicy.describe())
+	} else {
+		rows := make([]string, 0, len(pauseMenuItems))
+		for i, item := range pauseMenuItems {
+			available := item.unavailableReason == nil || item.unavailableReason(m) == ""
+			switch {
+			case i == p.selected:
+				// The marker shows the selected item without relying on colour
+				rows = append(rows, m.theme.highlightedStyle().Render("> "+item.name))
+			case !available:
+				rows = append(rows, m.theme.secondaryTextStyle().Render("  "+item.name))
+			default:
+				rows = append(rows, "  "+item.name)
+			}
+		}
+
+		selectedItem := pauseMenuItems[p.selected]
+		description := selectedItem.description
+		if selectedItem.unavailableReason != nil && selectedItem.unavailableReason(m) != "" {
+			description = selectedItem.unavailableReason(m)
+		}
+		if p.err != nil {
+			description = fmt.Sprintf("The game couldn't be saved: %v.", p.err)
+		}
+		if isVersusGame(m) {
+			description += " The game carries on for your opponent while it's paused."
+		}
+
+		text = lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.JoinVertical(lipgloss.Left, rows...),
+			"",
+			m.theme.secondaryTextStyle().Width(getMainViewWidth(m)).Render(description),
+		)
+	}
+
+	keys := p.keys
+	// The rules are a single page, so there's nothing to move between
+	keys.Up.SetEnabled(!p.showRules)
+	keys.Down.SetEnabled(!p.showRules)
+	m.help.Width = getMainViewWidth(m)
+	helpView := m.help.View(keys)
+
+	if isCompactLayout(m) {
+		// Leave out the heading and spacing, so the menu fits in small windows
+		return lipgloss.NewStyle().Width(getMainViewWidth(m)).Render(lipgloss.JoinVertical(lipgloss.Left, text,
+			helpView))
+	}
+
+	heading := "Paused"
+	if p.showRules {
+		heading = "How to play"
+	}
+	return lipgloss.NewStyle().Width(getMainViewWidth(m)).Render(lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Bold(true).Render(heading),
+		"",
+		text,
+		"",
+		helpView,
+	))
+}
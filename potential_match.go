@@ -8,14 +8,14 @@ import "sort"
 func findPotentialMatch(g grid) []vector2d {
 	filters := generatePotentialMatchFilters()
 
-	for y := gridHeight - 1; y >= 0; y-- {
-		for x := 0; x < gridWidth; x++ {
+	for y := g.height() - 1; y >= 0; y-- {
+		for x := 0; x < g.width(); x++ {
 			for _, f := range filters {
 				// Don't need to compute size; could just check all filter's points are within grid
 				filterSize := computeObjectSize(f)
 
 				// Check filter would be inside the grid when positioned at current x,y coords
-				if x >= gridWidth-filterSize.x+1 || y < filterSize.y-1 {
+				if x >= g.width()-filterSize.x+1 || y < filterSize.y-1 {
 					continue
 				}
 
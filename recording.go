@@ -0,0 +1,358 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Games can be recorded as asciicast v2 files (see https://docs.asciinema.org/manual/asciicast/v2/), which can be
+// played back with `asciinema play` or embedded in a web page with asciinema-player
+
+const castVersion = 2
+
+// Size of recordings rendered from an event log, which is large enough for the full layout with any board size
+var defaultReplaySize = vector2d{
+	x: 100,
+	y: 30,
+}
+
+type castHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Title     string `json:"title"`
+}
+
+// Writes frames drawn by the game to an asciicast file, with each frame's time relative to `startTime`
+type castRecorder struct {
+	w             io.Writer
+	startTime     time.Time
+	size          vector2d
+	headerWritten bool
+	cleared       bool   // Whether the screen has been cleared since the last resize
+	lastFrame     string // Frames that haven't changed aren't written again
+}
+
+func newCastRecorder(w io.Writer, startTime time.Time) *castRecorder {
+	return &castRecorder{w: w, startTime: startTime}
+}
+
+// Changes the size of the terminal, which takes effect from the next frame
+func (r *castRecorder) resize(size vector2d, t time.Time) error {
+	if size == r.size {
+		return nil
+	}
+
+	r.size = size
+	r.cleared = false
+	if !r.headerWritten {
+		return nil // The header has the size of the terminal at the start
+	}
+	return r.writeEvent(t, "r", fmt.Sprintf("%dx%d", size.x, size.y))
+}
+
+// Writes the frame (e.g. from `model.View`), unless it's the same as the previous frame
+// Frames are ignored until the size of the terminal is known
+func (r *castRecorder) writeFrame(frame string, t time.Time) error {
+	if r.size.x <= 0 || r.size.y <= 0 || (frame == r.lastFrame && r.cleared) {
+		return nil
+	}
+
+	if !r.headerWritten {
+		data, err := json.Marshal(castHeader{
+			Version:   castVersion,
+			Width:     r.size.x,
+			Height:    r.size.y,
+			Timestamp: r.startTime.Unix(),
+			Title:     "Match-Three Game",
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := r.w.Write(append(data, '\n')); err != nil {
+			return err
+		}
+		r.headerWritten = true
+	}
+
+	// Redraw the whole screen from the top left, clearing the rest of each line, as the player keeps what was drawn
+	// before
+	var output strings.Builder
+	if !r.cleared {
+		output.WriteString("\x1b[?25l\x1b[2J") // Hide the cursor and clear the screen
+	}
+	output.WriteString("\x1b[H")
+	for i, line := range strings.Split(frame, "\n") {
+		if i > 0 {
+			output.WriteString("\r\n")
+		}
+		output.WriteString(line)
+		output.WriteString("\x1b[K")
+	}
+
+	r.cleared = true
+	r.lastFrame = frame
+	return r.writeEvent(t, "o", output.String())
+}
+
+func (r *castRecorder) writeEvent(t time.Time, code string, data string) error {
+	elapsed := float64(t.Sub(r.startTime).Microseconds()) / float64(time.Second/time.Microsecond)
+	line, err := json.Marshal([]any{elapsed, code, data})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.w.Write(append(line, '\n'))
+	return err
+}
+
+// Wraps the model to record each frame it draws while the game is being played
+type recordingModel struct {
+	model    tea.Model
+	recorder *castRecorder
+}
+
+func (r recordingModel) Init() tea.Cmd {
+	return r.model.Init()
+}
+
+func (r recordingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		// Ignore errors, as the recording isn't essential to playing the game
+		_ = r.recorder.resize(vector2d{x: msg.Width, y: msg.Height}, time.Now())
+	}
+
+	var cmd tea.Cmd
+	r.model, cmd = r.model.Update(msg)
+	return r, cmd
+}
+
+func (r recordingModel) View() string {
+	view := r.model.View()
+	_ = r.recorder.writeFrame(view, time.Now())
+	return view
+}
+
+// An event from the event log (see `writeEventLogEntry`), with only the details needed to replay the game
+type replayEvent struct {
+	Time        time.Time `json:"time"`
+	Event       string    `json:"event"`
+	GameType    string    `json:"gameType"`
+	Seed        int64     `json:"seed"`
+	Grid        grid      `json:"grid"`
+	SymbolCount int       `json:"symbolCount"`
+	SymbolSet   string    `json:"symbolSet"`
+	HintPolicy  string    `json:"hintPolicy"`
+	PlayerCount int       `json:"playerCount"`
+	DailyDate   string    `json:"dailyDate"`
+	Point1      vector2d  `json:"point1"`
+	Point2      vector2d  `json:"point2"`
+}
+
+// Returns the events of the last game in the event log, starting with its "game-start" event
+func readLastGameEvents(path string) ([]replayEvent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open event log: %w", err)
+	}
+	defer f.Close()
+
+	var events []replayEvent
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) // Events with grids can be long
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		var e replayEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("could not parse line %d of event log: %w", lineNumber, err)
+		}
+
+		if e.Event == "game-start" {
+			events = []replayEvent{e}
+		} else if events != nil {
+			events = append(events, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read event log: %w", err)
+	}
+
+	if events == nil {
+		return nil, errors.New("there are no games in the event log")
+	}
+	return events, nil
+}
+
+// Renders the last game in the event log as an asciicast, showing each move at the time it was made
+// Animations are left out, as only the time of each move is logged
+func renderCastFromEventLog(eventLogPath string, w io.Writer, size vector2d, m model) error {
+	events, err := readLastGameEvents(eventLogPath)
+	if err != nil {
+		return err
+	}
+
+	start := events[0]
+	if start.PlayerCount > 1 {
+		return errors.New("hot-seat games can't be replayed")
+	}
+	gt, ok := findByName(gameTypes, start.GameType)
+	if !ok {
+		return fmt.Errorf("unknown game type %q", start.GameType)
+	}
+	bs, ok := findBoardSize(start.Grid.size())
+	if !ok {
+		return fmt.Errorf("unknown board size %dx%d", start.Grid.width(), start.Grid.height())
+	}
+	if s, ok := findByName(m.symbolSets, start.SymbolSet); ok {
+		m.symbolSet = s
+	}
+	if hp, ok := findByName(hintPolicies, start.HintPolicy); ok {
+		m.options.hintPolicy = hp // The score depends on the hint policy
+	}
+
+	// Each move is shown straight away, without waiting for the player to confirm it
+	m.options.reducedMotion = true
+	m.options.confirmMoves = false
+	m.statsReadOnly = true // Replaying a game shouldn't count towards the stats
+	m.gameSeed = start.Seed
+	m = newGame(m, gt, bs, start.SymbolCount)
+	m.dailyDate = start.DailyDate
+	if !slices.EqualFunc(m.grid, start.Grid, slices.Equal[[]int]) {
+		return errors.New("the game can't be replayed, as it was played with a different version of the game")
+	}
+
+	m.windowSize = size
+	if !isWindowLargeEnough(m) {
+		minWindowSize := getMinWindowSize(m)
+		return fmt.Errorf("the recording needs to be at least %dx%d to fit the board", minWindowSize.x,
+			minWindowSize.y)
+	}
+	updatedModel, _ := showSelectFirstPointView(m)
+	m = updatedModel.(model)
+
+	recorder := newCastRecorder(w, start.Time)
+	if err := recorder.resize(size, start.Time); err != nil {
+		return err
+	}
+	writeFrame := func(m model, t time.Time) error {
+		m.toasts = nil // Toasts would never expire, as time doesn't pass while rendering
+		return recorder.writeFrame(m.View(), t)
+	}
+	if err := writeFrame(m, start.Time); err != nil {
+		return err
+	}
+
+	// The grid is shown refreshing at the time of the move, then refreshed at the time of the move's last event
+	var refreshedTime time.Time
+	for _, e := range events[1:] {
+		switch e.Event {
+		case "hint", "preview", "swap", "game-over":
+			if !refreshedTime.IsZero() {
+				if err := writeFrame(m, refreshedTime); err != nil {
+					return err
+				}
+				refreshedTime = time.Time{}
+			}
+			if _, ok := m.view.(noPossibleMovesView); ok {
+				ensurePotentialMatch(&m.grid, m.rand, m.symbolCount)
+				updatedModel, _ = showSelectFirstPointView(m)
+				m = updatedModel.(model)
+			}
+		default:
+			if !refreshedTime.IsZero() {
+				refreshedTime = e.Time
+			}
+			continue
+		}
+
+		switch e.Event {
+		case "hint":
+			if v, ok := m.view.(*selectFirstPointView); ok {
+				m = v.showHintFor(m)
+			}
+		case "preview":
+			// Point 2 isn't shown being selected, so only the effect on the score is replayed
+			m.point1 = e.Point1
+			m.point2 = e.Point2
+			m = usePreview(m)
+		case "swap":
+			m.point1 = e.Point1
+			m.point2 = e.Point2
+			updatedModel, _ = swapPoints(m)
+			m = updatedModel.(model)
+			refreshedTime = e.Time
+		case "game-over":
+			if _, ok := m.view.(gameOverView); !ok {
+				updatedModel, _ = endGame(m)
+				m = updatedModel.(model)
+			}
+		}
+		if err := writeFrame(m, e.Time); err != nil {
+			return err
+		}
+
+		if v, ok := m.view.(refreshGridView); ok {
+			updatedModel, _ = v.skip(m)
+			m = updatedModel.(model)
+		}
+		if e.Event == "game-over" {
+			return nil
+		}
+	}
+
+	// The game wasn't finished (e.g. the game was quit), so show where it got to
+	if !refreshedTime.IsZero() {
+		return writeFrame(m, refreshedTime)
+	}
+	return nil
+}
+
+// Writes the recording rendered from the event log to the file at `path`, replacing it if it already exists
+func replayToFile(eventLogPath string, path string, size vector2d, m model) error {
+	// The recording isn't shown in this terminal, so use colours that most terminals can play it back with
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := renderCastFromEventLog(eventLogPath, f, size, m); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func findBoardSize(size vector2d) (boardSize, bool) {
+	for _, bs := range boardSizes {
+		if bs.dimensions() == size {
+			return bs, true
+		}
+	}
+	return 0, false
+}
+
+// Parses a terminal size such as "100x30"
+func parseTerminalSize(s string) (vector2d, error) {
+	width, height, ok := strings.Cut(s, "x")
+	if !ok {
+		return vector2d{}, fmt.Errorf("invalid size %q (should be e.g. 100x30)", s)
+	}
+	x, errX := strconv.Atoi(width)
+	y, errY := strconv.Atoi(height)
+	if errX != nil || errY != nil || x <= 0 || y <= 0 {
+		return vector2d{}, fmt.Errorf("invalid size %q (should be e.g. 100x30)", s)
+	}
+	return vector2d{x: x, y: y}, nil
+}
@@ -2,13 +2,12 @@ package main
 
 import (
 	"math/rand"
-	"slices"
 )
 
 func findEmptyPoints(g grid) []vector2d {
-	emptyPoints := make([]vector2d, 0, gridWidth*gridHeight)
-	for y := 0; y < gridHeight; y++ {
-		for x := 0; x < gridWidth; x++ {
+	emptyPoints := make([]vector2d, 0, g.width()*g.height())
+	for y := 0; y < g.height(); y++ {
+		for x := 0; x < g.width(); x++ {
 			if g[y][x] == emptySymbol {
 				emptyPoints = append(emptyPoints, vector2d{x: x, y: y})
 			}
@@ -17,56 +16,60 @@ func findEmptyPoints(g grid) []vector2d {
 	return emptyPoints
 }
 
-func newGridWithMatchesRemoved(r *rand.Rand) grid {
-	g := newGrid(r)
-	removeMatches(&g, r)
+func newGridWithMatchesRemoved(r *rand.Rand, size vector2d, symbolCount int) grid {
+	g := newGrid(r, size, symbolCount)
+	removeMatches(&g, r, symbolCount)
 	return g
 }
 
-func removeMatches(g *grid, r *rand.Rand) {
+func removeMatches(g *grid, r *rand.Rand, symbolCount int) {
 	finished := false
 	for !finished {
-		finished = refreshGrid(g, r, nil)
+		finished, _ = refreshGrid(g, r, symbolCount, nil)
 	}
 }
 
-func ensurePotentialMatch(g *grid, r *rand.Rand) {
+func ensurePotentialMatch(g *grid, r *rand.Rand, symbolCount int) {
 	potentialMatch := findPotentialMatch(*g)
 	for len(potentialMatch) == 0 {
 		// Check if there are any possible matches; if no possible matches then create a new grid
-		*g = newGridWithMatchesRemoved(r)
+		*g = newGridWithMatchesRemoved(r, g.size(), symbolCount)
 
 		potentialMatch = findPotentialMatch(*g)
 	}
 }
 
-func refreshGrid(g *grid, r *rand.Rand, score *int) bool {
+// Performs a single step of refreshing the grid - either clearing any matches or dropping symbols down to fill in the
+// cleared points
+// Returns whether the grid is finished refreshing, along with any matches that were cleared
+func refreshGrid(g *grid, r *rand.Rand, symbolCount int, score *int) (bool, [][]vector2d) {
 	emptyPoints := findEmptyPoints(*g)
 	if len(emptyPoints) == 0 {
 		matches := findMatches(*g)
 		if len(matches) == 0 {
-			return true
+			return true, nil
 		}
 
-		if score != nil {
-			matchesScore := computeMatchesScore(matches)
-			*score += matchesScore
-		}
+		clearMatches(g, matches, score)
 
-		points := flatten(matches)
+		return false, matches
+	}
 
-		// Set points in matches to empty
-		for _, p := range points {
-			g[p.y][p.x] = emptySymbol
-		}
+	dropSymbols(g, r, symbolCount)
 
-		return false
-	}
+	return false, nil
+}
 
-	// Shift symbols down and insert random symbol at top of column
-	shiftPoint(g, r)
+// Sets the points in the matches to empty, adding the matches' score to `score` (unless it's nil)
+func clearMatches(g *grid, matches [][]vector2d, score *int) {
+	if score != nil {
+		matchesScore := computeMatchesScore(matches)
+		*score += matchesScore
+	}
 
-	return false
+	for _, p := range flatten(matches) {
+		(*g)[p.y][p.x] = emptySymbol
+	}
 }
 
 func findMatches(g grid) [][]vector2d {
@@ -84,17 +87,17 @@ func findMatches(g grid) [][]vector2d {
 
 		d.y = -d.y
 
-		for i := gridHeight - 1; i >= offset.y; i-- {
-			for j := 0; j < gridWidth-offset.x; j++ {
+		for i := g.height() - 1; i >= offset.y; i-- {
+			for j := 0; j < g.width()-offset.x; j++ {
 				originPoint := vector2d{x: j, y: i}
-				match := make([]vector2d, 0, gridWidth) // todo: improve capacity calculation
+				match := make([]vector2d, 0, g.width()) // todo: improve capacity calculation
 				for {
 					currentPoint := vector2d{
 						x: j + (len(match) * d.x),
 						y: i + (len(match) * d.y),
 					}
 
-					if !isPointInsideGrid(currentPoint) {
+					if !g.contains(currentPoint) {
 						break
 					}
 
@@ -188,24 +191,37 @@ func flatten[T any](s [][]T) (flattened []T) {
 	return
 }
 
-func shiftPoint(g *grid, r *rand.Rand) {
-	emptyPoints := findEmptyPoints(*g)
-	m := make(map[int][]int, gridWidth)
-	for _, p := range emptyPoints {
-		if m[p.x] == nil {
-			m[p.x] = make([]int, 0, gridHeight)
-		}
+// A symbol that fell from `fromY` (which is negative for new symbols inserted above the grid) to `to`
+type symbolFall struct {
+	fromY int
+	to    vector2d
+}
 
-		m[p.x] = append(m[p.x], p.y)
-	}
+// Drops symbols down to fill in the empty points, inserting random symbols at the top of each column
+// Returns the symbols that fell, so the fall can be animated
+func dropSymbols(g *grid, r *rand.Rand, symbolCount int) []symbolFall {
+	falls := make([]symbolFall, 0, g.width()*g.height())
+	for x := 0; x < g.width(); x++ {
+		// Move each symbol down to the lowest free point, starting from the bottom of the column
+		targetY := g.height() - 1
+		for y := g.height() - 1; y >= 0; y-- {
+			if (*g)[y][x] == emptySymbol {
+				continue
+			}
 
-	for x, ys := range m {
-		// Want to shift lower points first - hence getting the lowest point (point with highest y value)
-		maxY := slices.Max(ys)
+			if y != targetY {
+				(*g)[targetY][x] = (*g)[y][x]
+				falls = append(falls, symbolFall{fromY: y, to: vector2d{x: x, y: targetY}})
+			}
+			targetY--
+		}
 
-		for y := maxY; y > 0; y-- {
-			g[y][x] = g[y-1][x]
+		// Fill the rest of the column with new symbols, which fall from above the grid
+		newSymbolCount := targetY + 1
+		for y := targetY; y >= 0; y-- {
+			(*g)[y][x] = r.Intn(symbolCount)
+			falls = append(falls, symbolFall{fromY: y - newSymbolCount, to: vector2d{x: x, y: y}})
 		}
-		g[0][x] = r.Intn(symbolCount)
 	}
+	return falls
 }
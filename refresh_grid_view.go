@@ -4,105 +4,204 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"slices"
 )
 
 func showRefreshGridView(m model) (tea.Model, tea.Cmd) {
-	m.view = newRefreshGridView()
-	m.point1 = emptyVector2d
-	m.point2 = emptyVector2d
 	m.help.ShowAll = false
 
-	return m, tickCmd()
+	return newRefreshGridView(m).clearNextMatches(m)
 }
 
 type refreshGridViewKeyMap struct {
-	EndGame key.Binding
-	Skip    key.Binding
+	Pause key.Binding
+	Skip  key.Binding
 }
 
-func newRefreshGridViewKeys() refreshGridViewKeyMap {
+func newRefreshGridViewKeys(m model) refreshGridViewKeyMap {
 	return refreshGridViewKeyMap{
-		EndGame: newEndGameKeyBinding(),
-		Skip: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("↵", "skip"),
-		),
+		Pause: newPauseKeyBinding(m),
+		Skip:  newKeyBinding(m, gameSkipAction, "skip"),
 	}
 }
 
 func (r refreshGridViewKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{r.Skip, r.EndGame}
+	return []key.Binding{r.Skip, r.Pause}
 }
 
 func (r refreshGridViewKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{r.Skip, r.EndGame},
+		{r.Skip, r.Pause},
 	}
 }
 
+// The grid is refreshed by repeatedly flashing then clearing any matches, then dropping symbols into the cleared
+// points, until there are no matches left
 type refreshGridView struct {
-	keys refreshGridViewKeyMap
+	keys    refreshGridViewKeyMap
+	player  animationPlayer
+	matches [][]vector2d // Matches being flashed, which are cleared once the flash animation has finished
 }
 
-func newRefreshGridView() refreshGridView {
+func newRefreshGridView(m model) refreshGridView {
 	return refreshGridView{
-		keys: newRefreshGridViewKeys(),
+		keys: newRefreshGridViewKeys(m),
 	}
 }
 
 func (r refreshGridView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
-	var skipped bool
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, r.keys.EndGame):
-			return showEndGameConfirmationView(m)
+		case key.Matches(msg, r.keys.Pause):
+			return showPauseView(m)
 		case key.Matches(msg, r.keys.Skip):
-			skipped = true
-		default:
+			return r.skip(m)
+		}
+	case animationFrameMsg:
+		if !r.player.advance(msg) {
 			return m, nil
 		}
-	case tickMsg:
-		skipped = false
-	default:
-		return m, nil
+		if !r.player.isFinished() {
+			m.view = r
+			return m, r.player.nextFrame()
+		}
+
+		if r.matches != nil {
+			return r.dropSymbols(m)
+		}
+		return r.clearNextMatches(m)
+	}
+
+	return m, nil
+}
+
+func (r refreshGridView) resumeAnimation(m model) (tea.Model, tea.Cmd) {
+	cmd := r.player.resume()
+	m.view = r
+	m.help.ShowAll = false
+
+	return m, cmd
+}
+
+// Scores any matches in the grid and flashes them before they're cleared
+// If there are no matches, the grid has finished refreshing
+func (r refreshGridView) clearNextMatches(m model) (tea.Model, tea.Cmd) {
+	matches := findMatches(m.grid)
+	if len(matches) == 0 {
+		return finishRefreshingGrid(m)
 	}
 
-	finished := false
-	var scorePointer *int // If hint was shown, don't update the score (both for the player's match and cascading matches)
-	if m.hintShown {
-		scorePointer = nil
-	} else {
-		scorePointer = &m.score
+	m = scoreMatches(m, matches)
+
+	r.matches = matches
+	r.player = newAnimationPlayer(m, flashAnimation{
+		grid:   m.grid,
+		points: flatten(matches),
+		steady: m.options.reducedMotion,
+	})
+	m.view = r
+
+	return m, r.player.nextFrame()
+}
+
+// Clears the flashed matches and drops symbols into the cleared points
+func (r refreshGridView) dropSymbols(m model) (tea.Model, tea.Cmd) {
+	m.grid = m.grid.clone() // The flash animation still uses the grid
+	clearMatches(&m.grid, r.matches, nil)
+	falls := dropSymbols(&m.grid, m.rand, m.symbolCount)
+	r.matches = nil
+
+	if m.options.reducedMotion {
+		return r.clearNextMatches(m)
 	}
+
+	r.player = newAnimationPlayer(m, fallAnimation{grid: m.grid, falls: falls})
+	m.view = r
+
+	return m, r.player.nextFrame()
+}
+
+// Refreshes the rest of the grid without any animations
+func (r refreshGridView) skip(m model) (tea.Model, tea.Cmd) {
+	m.grid = m.grid.clone() // The animation still uses the grid
+	if r.matches != nil {
+		clearMatches(&m.grid, r.matches, nil)
+		dropSymbols(&m.grid, m.rand, m.symbolCount)
+	}
+
 	for {
-		finished = refreshGrid(&m.grid, m.rand, scorePointer)
-
-		if finished {
-			isPlaying := m.options.gameType != LimitedMoves || m.moveCount < moveLimit
-			if isPlaying {
-				// Check if there is a potential match; if not, then navigate to "no possible moves" view to create a new grid
-				potentialMatch := findPotentialMatch(m.grid)
-				if len(potentialMatch) == 0 {
-					showNoPossibleMovesView(m)
-				}
-
-				return showSelectFirstPointView(m)
-			} else {
-				return showGameOverView(m, "No more moves left.")
-			}
+		matches := findMatches(m.grid)
+		if len(matches) == 0 {
+			return finishRefreshingGrid(m)
 		}
 
-		if !skipped {
-			return m, tickCmd()
-		}
+		m = scoreMatches(m, matches)
+		clearMatches(&m.grid, matches, nil)
+		dropSymbols(&m.grid, m.rand, m.symbolCount)
+	}
+}
+
+// Adds the score for the matches (depending on the hint policy, if a hint was shown) and announces them
+func scoreMatches(m model, matches [][]vector2d) model {
+	previousScore := m.score
+	// The hint policy applies to both the player's match and cascading matches
+	if points := m.hintPolicy.scoreMatches(computeMatchesScore(matches), m.hintShown); points > 0 {
+		m.score += points
+
+		// Copy rather than update the slice to avoid modifying the points of previous copies of the model
+		movePoints := slices.Clone(m.movePoints)
+		movePoints[len(movePoints)-1] += points
+		m.movePoints = movePoints
+		m = earnHints(m, previousScore)
+	}
+
+	m.cascadeDepth++
+	if len(m.moves) > 0 {
+		// Copy rather than update the slice to avoid modifying the moves of previous copies of the model
+		moves := slices.Clone(m.moves)
+		move := &moves[len(moves)-1]
+		move.matchCount += len(matches)
+		move.cascadeDepth = m.cascadeDepth
+		move.points += m.score - previousScore
+		m.moves = moves
+	}
+	m = emitGameEvent(m, cascadeStepEvent{depth: m.cascadeDepth, matchCount: len(matches), grid: m.grid})
+	for _, match := range matches {
+		symbol := m.grid[match[0].y][match[0].x]
+		m = emitGameEvent(m, matchEvent{match: match, symbol: symbol, cascadeDepth: m.cascadeDepth})
 	}
+	return emitGameEvent(m, scoreEvent{points: m.score - previousScore, score: m.score,
+		scored: !m.hintShown || m.hintPolicy != NoPointsHintPolicy})
+}
+
+func finishRefreshingGrid(m model) (tea.Model, tea.Cmd) {
+	if isVersusGame(m) {
+		sendVersusProgress(m)
+	}
+	if isHotSeatGame(m) {
+		// Players take turns, so the game is over once the last player has used up their moves
+		m = startNextTurn(m)
+	}
+
+	isPlaying := !m.gameType.hasMoveLimit() || m.moveCount < moveLimit
+	if !isPlaying {
+		return showGameOverView(m, "No more moves left.")
+	}
+
+	// Check if there is a potential match; if not, then navigate to "no possible moves" view to create a new grid
+	potentialMatch := findPotentialMatch(m.grid)
+	if len(potentialMatch) == 0 {
+		return showNoPossibleMovesView(m)
+	}
+
+	return showSelectFirstPointView(m)
 }
 
 func (r refreshGridView) draw(m model) string {
 	const text = "Refreshing grid..."
-	gridText := drawGrid(m, []vector2d{m.point1, m.point2})
-	m.help.Width = m.windowSize.x - lipgloss.Width(gridText) - 8 - 3
+	gridText := drawGridSprites(m, r.player.sprites())
+	m.help.Width = getGridLayoutTextWidth(m, gridText)
 	helpView := m.help.View(r.keys)
 	refreshGridText := lipgloss.JoinVertical(lipgloss.Left, text, "", helpView)
 	gridLayoutText := drawGridLayout(m, gridText, refreshGridText)
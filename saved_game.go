@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const savedGameFileName = "saved_game.json"
+
+// A game saved from the pause menu, which can be continued from the title screen
+// Only the seed and moves are saved, as the rest of the game can be worked out by making the same moves again
+type savedGame struct {
+	GameType        string      `json:"gameType"`
+	BoardSize       string      `json:"boardSize"`
+	SymbolCount     int         `json:"symbolCount"`
+	HintPolicy      string      `json:"hintPolicy"`
+	Seed            int64       `json:"seed"`
+	DailyDate       string      `json:"dailyDate,omitempty"`
+	DailyRanked     bool        `json:"dailyRanked,omitempty"`
+	PlayTimeSeconds int         `json:"playTimeSeconds"`
+	Moves           []savedMove `json:"moves"`
+	// Whether a hint (or the match preview) was shown for the move that hadn't been made yet
+	HintShown    bool `json:"hintShown,omitempty"`
+	PreviewShown bool `json:"previewShown,omitempty"`
+}
+
+type savedMove struct {
+	Point1    vector2d `json:"point1"`
+	Point2    vector2d `json:"point2"`
+	HintShown bool     `json:"hintShown,omitempty"`
+}
+
+// Returns the path of the saved game of the given user, or of the local player if `userID` is empty
+func getSavedGamePath(userID string) (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	if userID != "" {
+		return filepath.Join(filepath.Dir(configPath), userStatsDirName, userID+"_"+savedGameFileName), nil
+	}
+	return filepath.Join(filepath.Dir(configPath), savedGameFileName), nil
+}
+
+// Loads the saved game, returning nil if there isn't one
+func loadSavedGame(userID string) (*savedGame, error) {
+	path, err := getSavedGamePath(userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find config directory: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read saved game: %w", err)
+	}
+
+	var sg savedGame
+	if err := json.Unmarshal(data, &sg); err != nil {
+		return nil, fmt.Errorf("invalid saved game %s: %w", path, err)
+	}
+
+	return &sg, nil
+}
+
+func writeSavedGame(sg savedGame, userID string) error {
+	path, err := getSavedGamePath(userID)
+	if err != nil {
+		return fmt.Errorf("could not find config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(sg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("could not write saved game: %w", err)
+	}
+
+	return nil
+}
+
+// Removes the saved game once it's been continued, so it can only be continued once
+func removeSavedGame(userID string) error {
+	path, err := getSavedGamePath(userID)
+	if err != nil {
+		return fmt.Errorf("could not find config directory: %w", err)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not remove saved game: %w", err)
+	}
+	return nil
+}
+
+// Whether the current game can be saved, returning the reason if not
+func getSaveUnavailableReason(m model) string {
+	switch {
+	case isHotSeatGame(m):
+		return "Hot-seat games can't be saved."
+	case isVersusGame(m):
+		return "Versus games can't be saved, as your opponent carries on playing."
+	}
+	return ""
+}
+
+func newSavedGame(m model, playTime time.Duration) savedGame {
+	bs, _ := findBoardSize(m.grid.size())
+	moves := make([]savedMove, 0, len(m.moves))
+	for _, move := range m.moves {
+		moves = append(moves, savedMove{Point1: move.point1, Point2: move.point2, HintShown: move.hintShown})
+	}
+
+	return savedGame{
+		GameType:        m.gameType.String(),
+		BoardSize:       bs.String(),
+		SymbolCount:     m.symbolCount,
+		HintPolicy:      m.hintPolicy.String(),
+		Seed:            m.gameSeed,
+		DailyDate:       m.dailyDate,
+		DailyRanked:     m.gameType == Daily && m.dailyRanked,
+		PlayTimeSeconds: int(playTime.Seconds()),
+		Moves:           moves,
+		HintShown:       m.hintShown,
+		PreviewShown:    m.previewShown,
+	}
+}
+
+// Saves the current game and returns to the title screen, where it can be continued later
+func saveGameAndQuit(m model, playTime time.Duration) (tea.Model, tea.Cmd, error) {
+	sg := newSavedGame(m, playTime)
+	if err := writeSavedGame(sg, m.userID); err != nil {
+		return m, nil, err
+	}
+
+	m.savedGame = &sg
+	updatedModel, cmd := showTitleView(m)
+	return updatedModel, cmd, nil
+}
+
+var errSavedGameFromOtherVersion = errors.New("it's from another version of the game")
+
+// Sets up the saved game by making its moves again, without animations or announcements, then carries on playing it
+// If the moves can't be made again, the title screen is shown with the error instead
+func continueSavedGame(m model) (tea.Model, tea.Cmd) {
+	sg := *m.savedGame
+	m.savedGame = nil
+	m.savedGameErr = nil
+	// Ignore errors, as at worst the game could be continued again
+	_ = removeSavedGame(m.userID)
+
+	gt, ok := findByName(gameTypes, sg.GameType)
+	bs, ok2 := findByName(boardSizes, sg.BoardSize)
+	hp, ok3 := findByName(hintPolicies, sg.HintPolicy)
+	if !ok || !ok2 || !ok3 {
+		m.savedGameErr = errSavedGameFromOtherVersion
+		return showTitleView(m)
+	}
+
+	// Keep the parts of the model that are changed while the moves are made
+	titleModel := m
+	options := m.options
+	announcementLog := m.announcementLog
+	m.options.reducedMotion = true
+	m.options.confirmMoves = false
+	m.options.hintPolicy = hp // The game carries on with the hint policy it was started with
+	m.announcementLog = nil
+
+	m.gameSeed = sg.Seed
+	m = newGame(m, gt, bs, sg.SymbolCount)
+	m.dailyDate = sg.DailyDate
+	m.dailyRanked = sg.DailyRanked
+	// The moves are written to the event log again, so the log has the whole game
+	m = emitGameEvent(m, newGameStartEvent(m))
+	var updatedModel tea.Model = m
+	for _, move := range sg.Moves {
+		m = updatedModel.(model)
+		if _, ok := m.view.(noPossibleMovesView); ok {
+			ensurePotentialMatch(&m.grid, m.rand, m.symbolCount)
+		}
+		m.hintShown = false
+		if move.HintShown {
+			m = useHint(m)
+		}
+		m.point1 = move.Point1
+		m.point2 = move.Point2
+		updatedModel, _ = swapPoints(m)
+		if v, ok := updatedModel.(model).view.(refreshGridView); ok {
+			updatedModel, _ = v.skip(updatedModel.(model))
+		}
+	}
+	m = updatedModel.(model)
+	if m.moveCount != len(sg.Moves) {
+		// A move wasn't valid, so the grid must be different from when the game was saved
+		titleModel.savedGameErr = errSavedGameFromOtherVersion
+		return showTitleView(titleModel)
+	}
+
+	_, noPossibleMoves := m.view.(noPossibleMovesView)
+	if !noPossibleMoves {
+		updatedModel, _ = showSelectFirstPointView(m) // Starts the next move, so it's done before the hint is used
+		m = updatedModel.(model)
+		// The hint was already paid for (e.g. with points) before the game was saved, so it's used again rather than
+		// given back
+		if sg.HintShown {
+			m = useHint(m)
+		}
+		m.previewShown = sg.PreviewShown
+	}
+
+	// Achievement progress already includes the moves, so it's put back rather than counting them twice
+	m.stats = titleModel.stats
+	m.unlockedAchievements = titleModel.unlockedAchievements
+	m.options = options
+	m.announcementLog = announcementLog
+	m.announcements = nil
+	m.gameStartTime = time.Now().Add(-time.Duration(sg.PlayTimeSeconds) * time.Second)
+
+	if noPossibleMoves {
+		return showNoPossibleMovesView(m)
+	}
+	return returnToSelectFirstPointView(m) // Updates the keys now the hint and options are restored
+}
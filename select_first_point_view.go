@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -8,8 +9,11 @@ import (
 )
 
 func showSelectFirstPointView(m model) (tea.Model, tea.Cmd) {
-	m.help.ShowAll = false                                   // Important that this is updated before creating the view
-	m.point1 = vector2d{x: gridWidth / 2, y: gridHeight / 2} // Initialise point 1 to centre of grid
+	m.help.ShowAll = false // Important that this is updated before creating the view
+	// In swipe mode, the cursor stays where it was after the previous move
+	if m.options.inputMode != Swipe || !m.grid.contains(m.point1) {
+		m.point1 = vector2d{x: m.grid.width() / 2, y: m.grid.height() / 2} // Initialise point 1 to centre of grid
+	}
 	m.hintShown = false
 
 	s := newSelectFirstPointView(m)
@@ -27,95 +31,134 @@ func returnToSelectFirstPointView(m model) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func showSwapRejected(m model) (tea.Model, tea.Cmd) {
+	m.help.ShowAll = false // Important that this is updated before creating the view
+
+	s := newSelectFirstPointView(m)
+	s.swapRejected = true
+	m.view = &s
+
+	return m, nil
+}
+
 type selectFirstPointViewKeyMap struct {
-	EndGame    key.Binding
-	Help       key.Binding
-	Select     key.Binding
-	ToggleHint key.Binding
-	Up         key.Binding
-	Down       key.Binding
-	Left       key.Binding
-	Right      key.Binding
+	Pause       key.Binding
+	Help        key.Binding
+	Select      key.Binding
+	ToggleHint  key.Binding
+	MoveHistory key.Binding
+	Up          key.Binding
+	Down        key.Binding
+	Left        key.Binding
+	Right       key.Binding
+	SwapPrefix  key.Binding
+	SwapUp      key.Binding
+	SwapDown    key.Binding
+	SwapLeft    key.Binding
+	SwapRight   key.Binding
+	// Select an earlier or later move in the move history
+	MoveHistoryUp   key.Binding
+	MoveHistoryDown key.Binding
 }
 
 func newSelectFirstPointViewKeys(m model) selectFirstPointViewKeyMap {
-	return selectFirstPointViewKeyMap{
-		EndGame: newEndGameKeyBinding(),
-		Help:    newHelpKeyBinding(m),
-		Select: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("↵", "select"),
-		),
-		ToggleHint: key.NewBinding(
-			key.WithKeys("h"),
-			key.WithHelp("h", "show hint"),
-		),
-		Up: key.NewBinding(
-			key.WithKeys("up", "w"),
-			key.WithHelp("↑/w", "up"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys("down", "s"),
-			key.WithHelp("↓/s", "down"),
-		),
-		Left: key.NewBinding(
-			key.WithKeys("left", "a"),
-			key.WithHelp("←/a", "left"),
-		),
-		Right: key.NewBinding(
-			key.WithKeys("right", "d"),
-			key.WithHelp("→/d", "right"),
-		),
+	isSwipeMode := m.options.inputMode == Swipe
+	keys := selectFirstPointViewKeyMap{
+		Pause:      newPauseKeyBinding(m),
+		Help:       newHelpKeyBinding(m),
+		Select:     newKeyBinding(m, gameSelectAction, "select"),
+		ToggleHint: newKeyBinding(m, gameToggleHintAction, "show hint"),
+		Up:         newKeyBinding(m, gameUpAction, "up"),
+		Down:       newKeyBinding(m, gameDownAction, "down"),
+		Left:       newKeyBinding(m, gameLeftAction, "left"),
+		Right:      newKeyBinding(m, gameRightAction, "right"),
+		// Swap keys are only used in swipe mode
+		SwapPrefix: newKeyBinding(m, gameSwapPrefixAction, "swap (then press direction)"),
+		SwapUp:     newKeyBinding(m, gameSwapUpAction, "swap up"),
+		SwapDown:   newKeyBinding(m, gameSwapDownAction, "swap down"),
+		SwapLeft:   newKeyBinding(m, gameSwapLeftAction, "swap left"),
+		SwapRight:  newKeyBinding(m, gameSwapRightAction, "swap right"),
+	}.withSwapKeysEnabled(isSwipeMode)
+	keys.ToggleHint.SetEnabled(isHintAvailable(m))
+
+	return keys.withMoveHistoryKeys(m)
+}
+
+// Updates the move history keys, e.g. so the description ("show move history"/"hide move history") is up to date
+func (k selectFirstPointViewKeyMap) withMoveHistoryKeys(m model) selectFirstPointViewKeyMap {
+	moves, _ := getMoveHistory(m)
+	if m.moveHistoryShown {
+		k.MoveHistory = newKeyBinding(m, gameMoveHistoryAction, "hide move history")
+	} else {
+		k.MoveHistory = newKeyBinding(m, gameMoveHistoryAction, "show move history")
 	}
+	k.MoveHistory.SetEnabled(m.moveHistoryShown || len(moves) > 0)
+	k.MoveHistoryUp = newKeyBinding(m, gameMoveHistoryUpAction, "earlier move")
+	k.MoveHistoryUp.SetEnabled(m.moveHistoryShown && len(moves) > 0)
+	k.MoveHistoryDown = newKeyBinding(m, gameMoveHistoryDownAction, "later move")
+	k.MoveHistoryDown.SetEnabled(m.moveHistoryShown && m.moveHistorySelected != noSelectedMove)
+	return k
+}
+
+func (k selectFirstPointViewKeyMap) withSwapKeysEnabled(enabled bool) selectFirstPointViewKeyMap {
+	k.SwapPrefix.SetEnabled(enabled)
+	k.SwapUp.SetEnabled(enabled)
+	k.SwapDown.SetEnabled(enabled)
+	k.SwapLeft.SetEnabled(enabled)
+	k.SwapRight.SetEnabled(enabled)
+	return k
 }
 
 func (k selectFirstPointViewKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Help, k.EndGame}
+	return []key.Binding{k.Help, k.Pause}
 }
 
 func (k selectFirstPointViewKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right, k.Select, k.ToggleHint},
-		{k.Help, k.EndGame},
+		{k.SwapUp, k.SwapDown, k.SwapLeft, k.SwapRight, k.SwapPrefix},
+		{k.MoveHistory, k.MoveHistoryUp, k.MoveHistoryDown},
+		{k.Help, k.Pause},
 	}
 }
 
 type selectFirstPointViewHintKeyMap struct {
-	EndGame    key.Binding
+	Pause      key.Binding
 	ToggleHint key.Binding
 }
 
-func newSelectFirstPointViewHintKeys() selectFirstPointViewHintKeyMap {
+func newSelectFirstPointViewHintKeys(m model) selectFirstPointViewHintKeyMap {
 	return selectFirstPointViewHintKeyMap{
-		EndGame: newEndGameKeyBinding(),
-		ToggleHint: key.NewBinding(
-			key.WithKeys("h"),
-			key.WithHelp("h", "hide hint"),
-		),
+		Pause:      newPauseKeyBinding(m),
+		ToggleHint: newKeyBinding(m, gameToggleHintAction, "hide hint"),
 	}
 }
 
 func (k selectFirstPointViewHintKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.ToggleHint, k.EndGame}
+	return []key.Binding{k.ToggleHint, k.Pause}
 }
 
 func (k selectFirstPointViewHintKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.ToggleHint, k.EndGame},
+		{k.ToggleHint, k.Pause},
 	}
 }
 
 type selectFirstPointView struct {
-	showHint bool
-	keys     selectFirstPointViewKeyMap
-	hintKeys selectFirstPointViewHintKeyMap
+	showHint     bool
+	swapPending  bool // Whether the swap prefix key was pressed, so the next direction key swaps
+	swapRejected bool // Whether the previous swap was rejected, when skipping the confirmation view
+	keys         selectFirstPointViewKeyMap
+	hintKeys     selectFirstPointViewHintKeyMap
 }
 
 func newSelectFirstPointView(m model) selectFirstPointView {
 	return selectFirstPointView{
-		showHint: false,
-		keys:     newSelectFirstPointViewKeys(m),
-		hintKeys: newSelectFirstPointViewHintKeys(),
+		showHint:     false,
+		swapPending:  false,
+		swapRejected: false,
+		keys:         newSelectFirstPointViewKeys(m),
+		hintKeys:     newSelectFirstPointViewHintKeys(m),
 	}
 }
 
@@ -124,48 +167,122 @@ func (s *selectFirstPointView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd)
 	case tea.KeyMsg:
 		if s.showHint {
 			switch {
-			case key.Matches(msg, s.hintKeys.EndGame):
-				return showEndGameConfirmationView(m)
+			case key.Matches(msg, s.hintKeys.Pause):
+				return showPauseView(m)
 			case key.Matches(msg, s.hintKeys.ToggleHint):
 				s.showHint = false
 			}
 			return m, nil
 		}
 
+		s.swapRejected = false
+
+		if s.swapPending {
+			s.swapPending = false
+			switch {
+			case key.Matches(msg, s.keys.Up):
+				return s.swapWithNeighbour(m, vector2d{x: 0, y: -1})
+			case key.Matches(msg, s.keys.Down):
+				return s.swapWithNeighbour(m, vector2d{x: 0, y: 1})
+			case key.Matches(msg, s.keys.Left):
+				return s.swapWithNeighbour(m, vector2d{x: -1, y: 0})
+			case key.Matches(msg, s.keys.Right):
+				return s.swapWithNeighbour(m, vector2d{x: 1, y: 0})
+			}
+		}
+
 		switch {
-		case key.Matches(msg, s.keys.EndGame):
-			return showEndGameConfirmationView(m)
+		case key.Matches(msg, s.keys.Pause):
+			return showPauseView(m)
 		case key.Matches(msg, s.keys.Help):
 			return s.toggleHelp(m)
 
 		case key.Matches(msg, s.keys.ToggleHint):
-			// Show hint
-			s.showHint = true
-
-			// Update flag so match isn't scored
-			m.hintShown = true
+			m.moveHistorySelected = noSelectedMove // So only the hint is highlighted
+			m = s.showHintFor(m)
+		case key.Matches(msg, s.keys.MoveHistory):
+			m = toggleMoveHistory(m)
+			s.keys = s.keys.withMoveHistoryKeys(m)
+		case key.Matches(msg, s.keys.MoveHistoryUp):
+			m = selectPreviousMove(m)
+			s.keys = s.keys.withMoveHistoryKeys(m)
+		case key.Matches(msg, s.keys.MoveHistoryDown):
+			m = selectNextMove(m)
+			s.keys = s.keys.withMoveHistoryKeys(m)
 
 		case key.Matches(msg, s.keys.Select):
+			m.moveHistorySelected = noSelectedMove // So only the selected points are highlighted
 			return showSelectSecondPointView(m)
 
+		case key.Matches(msg, s.keys.SwapPrefix):
+			s.swapPending = true
+		case key.Matches(msg, s.keys.SwapUp):
+			return s.swapWithNeighbour(m, vector2d{x: 0, y: -1})
+		case key.Matches(msg, s.keys.SwapDown):
+			return s.swapWithNeighbour(m, vector2d{x: 0, y: 1})
+		case key.Matches(msg, s.keys.SwapLeft):
+			return s.swapWithNeighbour(m, vector2d{x: -1, y: 0})
+		case key.Matches(msg, s.keys.SwapRight):
+			return s.swapWithNeighbour(m, vector2d{x: 1, y: 0})
+
 		case key.Matches(msg, s.keys.Up):
 			m.point1.y--
-			m.point1.y = (m.point1.y + gridHeight) % gridHeight // Clamp y coordinate between 0 and gridHeight - 1
+			m.point1.y = (m.point1.y + m.grid.height()) % m.grid.height() // Wrap around to the other side
 		case key.Matches(msg, s.keys.Down):
 			m.point1.y++
-			m.point1.y = (m.point1.y + gridHeight) % gridHeight // Clamp y coordinate between 0 and gridHeight - 1
+			m.point1.y = (m.point1.y + m.grid.height()) % m.grid.height() // Wrap around to the other side
 		case key.Matches(msg, s.keys.Left):
 			m.point1.x--
-			m.point1.x = (m.point1.x + gridWidth) % gridWidth // Clamp x coordinate between 0 and gridWidth - 1
+			m.point1.x = (m.point1.x + m.grid.width()) % m.grid.width() // Wrap around to the other side
 		case key.Matches(msg, s.keys.Right):
 			m.point1.x++
-			m.point1.x = (m.point1.x + gridWidth) % gridWidth // Clamp x coordinate between 0 and gridWidth - 1
+			m.point1.x = (m.point1.x + m.grid.width()) % m.grid.width() // Wrap around to the other side
+		}
+	case tea.MouseMsg:
+		if s.showHint {
+			return m, nil
+		}
+
+		point, ok := getGridPointAtScreenPosition(m, vector2d{x: msg.X, y: msg.Y})
+		if !ok {
+			return m, nil
+		}
+
+		switch {
+		case msg.Action == tea.MouseActionMotion:
+			// Move the cursor to the hovered point, so it's highlighted
+			m.point1 = point
+		case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+			// Pressing (rather than releasing) selects the point, so dragging to a neighbour can swap them
+			m.point1 = point
+			m.moveHistorySelected = noSelectedMove // So only the selected points are highlighted
+			return showSelectSecondPointView(m)
 		}
 	}
 
 	return m, nil
 }
 
+// Swaps point 1 with the neighbouring point in the given direction, without selecting a second point
+func (s *selectFirstPointView) swapWithNeighbour(m model, direction vector2d) (tea.Model, tea.Cmd) {
+	neighbour := vector2d{
+		x: m.point1.x + direction.x,
+		y: m.point1.y + direction.y,
+	}
+	if !m.grid.contains(neighbour) {
+		return m, nil
+	}
+
+	m.point2 = neighbour
+	return swapPoints(m)
+}
+
+func (s *selectFirstPointView) showHintFor(m model) model {
+	s.showHint = true
+	m = useHint(m)
+	return emitGameEvent(m, hintEvent{potentialMatch: findPotentialMatch(m.grid)})
+}
+
 // todo: combine the two copies of this function (?)
 func (s *selectFirstPointView) toggleHelp(m model) (tea.Model, tea.Cmd) {
 	// Toggle between short and full help in help view
@@ -177,9 +294,9 @@ func (s *selectFirstPointView) toggleHelp(m model) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func getInitialPoint2(point1 vector2d) vector2d {
+func getInitialPoint2(g grid, point1 vector2d) vector2d {
 	if point1.y == 0 {
-		if point1.x == gridWidth-1 {
+		if point1.x == g.width()-1 {
 			return vector2d{
 				x: point1.x - 1,
 				y: point1.y,
@@ -203,7 +320,7 @@ func (s *selectFirstPointView) draw(m model) string {
 	if s.showHint {
 		selectedPoints = findPotentialMatch(m.grid)
 	} else {
-		selectedPoints = []vector2d{m.point1}
+		selectedPoints = append([]vector2d{m.point1}, getSelectedMovePoints(m)...)
 	}
 	gridText := drawGrid(m, selectedPoints)
 
@@ -211,9 +328,23 @@ func (s *selectFirstPointView) draw(m model) string {
 	if s.showHint {
 		text = "Showing hint."
 	} else {
-		text = "Select two points to swap (selecting point 1)..."
-		if m.hintShown {
-			text += "\n\nNo points for this move since hint was shown."
+		switch {
+		case s.swapPending:
+			text = "Press a direction to swap..."
+		case m.options.inputMode == Swipe:
+			text = "Move the cursor and swap with a neighbouring point..."
+		default:
+			text = "Select two points to swap (selecting point 1)..."
+		}
+		if s.swapRejected {
+			text += "\n\nNot swapped as swap would not result in a match."
+		}
+		if hintScoreText := drawHintScoreText(m); hintScoreText != "" {
+			text += "\n\n" + hintScoreText
+		}
+		if m.options.accessible {
+			text += fmt.Sprintf("\n\nCursor: %s at %s.", m.symbolSet.getSymbolString(m.grid[m.point1.y][m.point1.x]),
+				formatPoint(m.point1))
 		}
 	}
 
@@ -223,7 +354,7 @@ func (s *selectFirstPointView) draw(m model) string {
 	} else {
 		keys = s.keys
 	}
-	m.help.Width = m.windowSize.x - lipgloss.Width(gridText) - 8 - 3
+	m.help.Width = getGridLayoutTextWidth(m, gridText)
 	helpView := m.help.View(keys)
 	selectFirstPointText := lipgloss.JoinVertical(lipgloss.Left, text, "", helpView)
 
@@ -9,34 +9,31 @@ import (
 )
 
 func showSelectPointConfirmationView(m model) (tea.Model, tea.Cmd) {
-	m.view = newSelectPointConfirmationView()
+	m.view = newSelectPointConfirmationView(m)
 	m.help.ShowAll = false
 
 	return m, nil
 }
 
 type selectPointConfirmationViewKeyMap struct {
-	EndGame key.Binding
+	Pause   key.Binding
 	Confirm key.Binding
 }
 
-func newSelectPointConfirmationViewKeys() selectPointConfirmationViewKeyMap {
+func newSelectPointConfirmationViewKeys(m model) selectPointConfirmationViewKeyMap {
 	return selectPointConfirmationViewKeyMap{
-		EndGame: newEndGameKeyBinding(),
-		Confirm: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("↵", "continue"),
-		),
+		Pause:   newPauseKeyBinding(m),
+		Confirm: newKeyBinding(m, gameContinueAction, "continue"),
 	}
 }
 
 func (s selectPointConfirmationViewKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{s.Confirm, s.EndGame}
+	return []key.Binding{s.Confirm, s.Pause}
 }
 
 func (s selectPointConfirmationViewKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{s.Confirm, s.EndGame},
+		{s.Confirm, s.Pause},
 	}
 }
 
@@ -44,9 +41,9 @@ type selectPointConfirmationView struct {
 	keys selectPointConfirmationViewKeyMap
 }
 
-func newSelectPointConfirmationView() selectPointConfirmationView {
+func newSelectPointConfirmationView(m model) selectPointConfirmationView {
 	return selectPointConfirmationView{
-		keys: newSelectPointConfirmationViewKeys(),
+		keys: newSelectPointConfirmationViewKeys(m),
 	}
 }
 
@@ -54,8 +51,8 @@ func (s selectPointConfirmationView) update(msg tea.Msg, m model) (tea.Model, te
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, s.keys.EndGame):
-			return showEndGameConfirmationView(m)
+		case key.Matches(msg, s.keys.Pause):
+			return showPauseView(m)
 		case key.Matches(msg, s.keys.Confirm):
 			matches := findMatches(m.grid)
 			if len(matches) == 0 {
@@ -77,16 +74,20 @@ func (s selectPointConfirmationView) draw(m model) string {
 		symbol1 := m.grid[m.point1.y][m.point1.x]
 		symbol2 := m.grid[m.point2.y][m.point2.x]
 		swappedText := fmt.Sprintf("Swapped %s (%d, %d) and %s (%d, %d).",
-			m.symbolSet.formatSymbol(symbol1), m.point1.x, m.point1.y, m.symbolSet.formatSymbol(symbol2), m.point2.x,
-			m.point2.y)
+			m.symbolSet.formatSymbol(symbol1, m.theme), m.point1.x, m.point1.y, m.symbolSet.formatSymbol(symbol2, m.theme),
+			m.point2.x, m.point2.y)
 
 		matchText := fmt.Sprintf("%s formed!", english.PluralWord(len(matches), "Match", ""))
 
 		var pointsGainedText string
-		if m.hintShown {
+		matchesScore := m.hintPolicy.scoreMatches(computeMatchesScore(matches), m.hintShown)
+		switch {
+		case m.hintShown && m.hintPolicy == NoPointsHintPolicy:
 			pointsGainedText = "No points since hint was shown."
-		} else {
-			matchesScore := computeMatchesScore(matches)
+		case m.hintShown && m.hintPolicy == PenaltyHintPolicy:
+			pointsGainedText = fmt.Sprintf("+%d points (%d%% fewer since hint was shown).", matchesScore,
+				hintPenaltyPercent)
+		default:
 			pointsGainedText = fmt.Sprintf("+%d points!", matchesScore)
 		}
 
@@ -99,7 +100,7 @@ func (s selectPointConfirmationView) draw(m model) string {
 	}
 
 	gridText := drawGrid(m, selectedPoints)
-	m.help.Width = m.windowSize.x - lipgloss.Width(gridText) - 8 - 3
+	m.help.Width = getGridLayoutTextWidth(m, gridText)
 	helpView := m.help.View(s.keys)
 	selectPointConfirmationText := lipgloss.JoinVertical(lipgloss.Left, text, "", helpView)
 
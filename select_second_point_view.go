@@ -1,14 +1,20 @@
 package main
 
 import (
+	"fmt"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	"slices"
 )
 
 func showSelectSecondPointView(m model) (tea.Model, tea.Cmd) {
 	m.help.ShowAll = false // Important that this is updated before creating the view
-	m.point2 = getInitialPoint2(m.point1)
+	m.point2 = getInitialPoint2(m.grid, m.point1)
+	if m.previewShown && isHintAvailable(m) {
+		m = usePreview(m)
+	}
 
 	s := newSelectSecondPointView(m)
 	m.view = &s
@@ -17,55 +23,48 @@ func showSelectSecondPointView(m model) (tea.Model, tea.Cmd) {
 }
 
 type selectSecondPointViewKeyMap struct {
-	EndGame key.Binding
-	Help    key.Binding
-	Select  key.Binding
-	Cancel  key.Binding
-	Up      key.Binding
-	Down    key.Binding
-	Left    key.Binding
-	Right   key.Binding
+	Pause  key.Binding
+	Help   key.Binding
+	Select key.Binding
+	Cancel key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Left   key.Binding
+	Right  key.Binding
+	// Previews the outcome of the swap, which counts as a hint
+	TogglePreview key.Binding
 }
 
 func newSelectSecondPointViewKeys(m model) selectSecondPointViewKeyMap {
-	return selectSecondPointViewKeyMap{
-		EndGame: newEndGameKeyBinding(),
-		Help:    newHelpKeyBinding(m),
-		Select: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("↵", "select"),
-		),
-		Cancel: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("esc", "cancel"),
-		),
-		Up: key.NewBinding(
-			key.WithKeys("up", "w"),
-			key.WithHelp("↑/w", "up"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys("down", "s"),
-			key.WithHelp("↓/s", "down"),
-		),
-		Left: key.NewBinding(
-			key.WithKeys("left", "a"),
-			key.WithHelp("←/a", "left"),
-		),
-		Right: key.NewBinding(
-			key.WithKeys("right", "d"),
-			key.WithHelp("→/d", "right"),
-		),
+	keys := selectSecondPointViewKeyMap{
+		Pause:  newPauseKeyBinding(m),
+		Help:   newHelpKeyBinding(m),
+		Select: newKeyBinding(m, gameSelectAction, "select"),
+		Cancel: newKeyBinding(m, gameCancelAction, "cancel"),
+		Up:     newKeyBinding(m, gameUpAction, "up"),
+		Down:   newKeyBinding(m, gameDownAction, "down"),
+		Left:   newKeyBinding(m, gameLeftAction, "left"),
+		Right:  newKeyBinding(m, gameRightAction, "right"),
+	}
+	if m.previewShown {
+		keys.TogglePreview = newKeyBinding(m, gameTogglePreviewAction, "hide preview")
+	} else {
+		keys.TogglePreview = newKeyBinding(m, gameTogglePreviewAction, "show preview")
 	}
+	// The preview can always be turned off, even if there are no hints left to turn it back on
+	keys.TogglePreview.SetEnabled(m.previewShown || isHintAvailable(m))
+
+	return keys
 }
 
 func (s selectSecondPointViewKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{s.Help, s.EndGame}
+	return []key.Binding{s.Help, s.Pause}
 }
 
 func (s selectSecondPointViewKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{s.Up, s.Down, s.Left, s.Right, s.Select, s.Cancel},
-		{s.Help, s.EndGame},
+		{s.Up, s.Down, s.Left, s.Right, s.Select, s.Cancel, s.TogglePreview},
+		{s.Help, s.Pause},
 	}
 }
 
@@ -83,26 +82,23 @@ func (s *selectSecondPointView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, s.keys.EndGame):
-			return showEndGameConfirmationView(m)
+		case key.Matches(msg, s.keys.Pause):
+			return showPauseView(m)
 		case key.Matches(msg, s.keys.Help):
 			return s.toggleHelp(m)
 
 		case key.Matches(msg, s.keys.Select):
-			// Swap the points, if it would result in a match
-			updatedGrid := m.grid
-			updatedGrid[m.point1.y][m.point1.x], updatedGrid[m.point2.y][m.point2.x] =
-				updatedGrid[m.point2.y][m.point2.x], updatedGrid[m.point1.y][m.point1.x]
-			matches := findMatches(updatedGrid)
-			if len(matches) != 0 {
-				m.grid = updatedGrid
-
-				m.moveCount++
-			}
-
-			return showSelectPointConfirmationView(m)
+			return swapPoints(m)
 		case key.Matches(msg, s.keys.Cancel):
 			return returnToSelectFirstPointView(m)
+		case key.Matches(msg, s.keys.TogglePreview):
+			m.previewShown = !m.previewShown
+			if m.previewShown {
+				m = usePreview(m)
+			}
+			// Update the key so its description ("show preview"/"hide preview") is updated accordingly
+			s.keys = newSelectSecondPointViewKeys(m)
+			return m, nil
 		}
 
 		var point2Updated vector2d
@@ -130,14 +126,141 @@ func (s *selectSecondPointView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd
 		default:
 			return m, nil
 		}
-		if isPointInsideGrid(point2Updated) {
+		if m.grid.contains(point2Updated) {
 			m.point2 = point2Updated
 		}
+	case tea.MouseMsg:
+		point, ok := getGridPointAtScreenPosition(m, vector2d{x: msg.X, y: msg.Y})
+		if !ok {
+			return m, nil
+		}
+
+		isAdjacent := arePointsAdjacent(m.point1, point)
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			// Highlight the hovered point if it can be swapped with point 1 (also covers dragging from point 1)
+			if isAdjacent {
+				m.point2 = point
+			}
+		case tea.MouseActionPress:
+			if msg.Button != tea.MouseButtonLeft {
+				return m, nil
+			}
+
+			switch {
+			case point == m.point1:
+				return returnToSelectFirstPointView(m)
+			case isAdjacent:
+				m.point2 = point
+			default:
+				// Clicking a point that can't be swapped with point 1 selects it as point 1 instead
+				m.point1 = point
+				m.point2 = getInitialPoint2(m.grid, point)
+			}
+		case tea.MouseActionRelease:
+			// Releasing on a neighbour of point 1 completes either a click or a drag
+			if isAdjacent {
+				m.point2 = point
+				return swapPoints(m)
+			}
+		}
 	}
 
 	return m, nil
 }
 
+// Counts the preview as a hint, so the move doesn't score any points
+func usePreview(m model) model {
+	// Only count it once, even if a hint was also shown before the move
+	if m.hintShown {
+		return m
+	}
+
+	m = useHint(m)
+	return emitGameEvent(m, previewEvent{point1: m.point1, point2: m.point2})
+}
+
+// Works out what swapping the points would match, without changing the grid
+// Returns the points (before the swap) of the symbols that would be matched, and the points the matches would score,
+// not counting any cascades
+func getSwapPreview(m model) ([]vector2d, int) {
+	updatedGrid := m.grid.clone()
+	updatedGrid[m.point1.y][m.point1.x], updatedGrid[m.point2.y][m.point2.x] =
+		updatedGrid[m.point2.y][m.point2.x], updatedGrid[m.point1.y][m.point1.x]
+	matches := findMatches(updatedGrid)
+
+	var matchedPoints []vector2d
+	for _, match := range matches {
+		for _, p := range match {
+			// The swapped symbols are matched in each other's places
+			switch p {
+			case m.point1:
+				p = m.point2
+			case m.point2:
+				p = m.point1
+			}
+			if !slices.Contains(matchedPoints, p) {
+				matchedPoints = append(matchedPoints, p)
+			}
+		}
+	}
+	return matchedPoints, computeMatchesScore(matches)
+}
+
+func swapPoints(m model) (tea.Model, tea.Cmd) {
+	// Swap the points, if it would result in a match
+	updatedGrid := m.grid.clone()
+	updatedGrid[m.point1.y][m.point1.x], updatedGrid[m.point2.y][m.point2.x] =
+		updatedGrid[m.point2.y][m.point2.x], updatedGrid[m.point1.y][m.point1.x]
+	matches := findMatches(updatedGrid)
+	m = emitGameEvent(m, swapEvent{
+		point1:  m.point1,
+		point2:  m.point2,
+		symbol1: m.grid[m.point1.y][m.point1.x],
+		symbol2: m.grid[m.point2.y][m.point2.x],
+		valid:   len(matches) != 0,
+	})
+
+	if m.options.reducedMotion {
+		return completeSwap(m, len(matches) != 0)
+	}
+	return showSwapAnimationView(m, len(matches) != 0)
+}
+
+// Swaps the points if `valid` (i.e. the swap results in a match), then moves on to refreshing the grid (or
+// confirmation)
+func completeSwap(m model, valid bool) (tea.Model, tea.Cmd) {
+	if valid {
+		m.grid = m.grid.clone() // The swap animation still uses the grid
+		m.grid[m.point1.y][m.point1.x], m.grid[m.point2.y][m.point2.x] =
+			m.grid[m.point2.y][m.point2.x], m.grid[m.point1.y][m.point1.x]
+
+		m.moveCount++
+		m.movePoints = append(slices.Clip(m.movePoints), -getHintPointCost(m))
+		m.moves = append(slices.Clip(m.moves), gameMove{
+			point1:    m.point1,
+			point2:    m.point2,
+			hintShown: m.hintShown,
+			symbol1:   m.grid[m.point2.y][m.point2.x], // Already swapped
+			symbol2:   m.grid[m.point1.y][m.point1.x],
+			player:    m.currentPlayer,
+			points:    -getHintPointCost(m),
+		})
+		m.cascadeDepth = 0
+		m.moveHistorySelected = noSelectedMove // The moves listed have changed
+	}
+
+	// In swipe mode the cursor stays where it is, so an invalid swipe is rejected straight away to try another direction
+	if !valid && (m.options.inputMode == Swipe || !m.options.confirmMoves) {
+		return showSwapRejected(m)
+	}
+	if !m.options.confirmMoves {
+		return showRefreshGridView(m)
+	}
+
+	return showSelectPointConfirmationView(m)
+}
+
 // todo: combine the two copies of this function (?)
 func (s *selectSecondPointView) toggleHelp(m model) (tea.Model, tea.Cmd) {
 	// Toggle between short and full help in help view
@@ -150,9 +273,30 @@ func (s *selectSecondPointView) toggleHelp(m model) (tea.Model, tea.Cmd) {
 }
 
 func (s *selectSecondPointView) draw(m model) string {
-	const text = "Select two points to swap (selecting point 2)..."
-	gridText := drawGrid(m, []vector2d{m.point1, m.point2})
-	m.help.Width = m.windowSize.x - lipgloss.Width(gridText) - 8 - 3
+	text := "Select two points to swap (selecting point 2)..."
+	if m.options.accessible {
+		text += fmt.Sprintf("\n\nSwapping %s at %s with %s at %s.",
+			m.symbolSet.getSymbolString(m.grid[m.point1.y][m.point1.x]), formatPoint(m.point1),
+			m.symbolSet.getSymbolString(m.grid[m.point2.y][m.point2.x]), formatPoint(m.point2))
+	}
+	selectedPoints := []vector2d{m.point1, m.point2}
+	if m.previewShown && !m.hintShown {
+		text += "\n\nPreview: no hints left."
+	} else if m.previewShown {
+		matchedPoints, points := getSwapPreview(m)
+		if len(matchedPoints) == 0 {
+			text += "\n\nPreview: no match, so this swap won't be made."
+		} else {
+			text += fmt.Sprintf("\n\nPreview: matches %d symbols, worth %s points plus any cascades.",
+				len(matchedPoints), humanize.Comma(int64(m.hintPolicy.scoreMatches(points, true))))
+			selectedPoints = append(selectedPoints, matchedPoints...)
+		}
+	}
+	if hintScoreText := drawHintScoreText(m); hintScoreText != "" {
+		text += "\n\n" + hintScoreText
+	}
+	gridText := drawGrid(m, selectedPoints)
+	m.help.Width = getGridLayoutTextWidth(m, gridText)
 	helpView := m.help.View(s.keys)
 	selectSecondPointText := lipgloss.JoinVertical(lipgloss.Left, text, "", helpView)
 	gridLayoutText := drawGridLayout(m, gridText, selectSecondPointText)
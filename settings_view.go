@@ -0,0 +1,377 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strconv"
+	"strings"
+)
+
+// Shows the settings, returning to the current view when closed
+func showSettingsView(m model) (tea.Model, tea.Cmd) {
+	m.help.ShowAll = false
+
+	s := settingsView{
+		keys:       newSettingsViewKeys(m),
+		selected:   0,
+		returnView: m.view,
+	}
+	m.view = &s
+
+	return m, nil
+}
+
+type settingsViewKeyMap struct {
+	Up            key.Binding
+	Down          key.Binding
+	NextValue     key.Binding
+	PreviousValue key.Binding
+	Reset         key.Binding
+	Back          key.Binding
+}
+
+func newSettingsViewKeys(m model) settingsViewKeyMap {
+	return settingsViewKeyMap{
+		Up:            newKeyBinding(m, settingsUpAction, "up"),
+		Down:          newKeyBinding(m, settingsDownAction, "down"),
+		NextValue:     newKeyBinding(m, settingsNextValueAction, "next"),
+		PreviousValue: newKeyBinding(m, settingsPreviousValueAction, "previous"),
+		Reset:         newKeyBinding(m, settingsResetAction, "reset all"),
+		Back:          newKeyBinding(m, settingsBackAction, "back"),
+	}
+}
+
+func (k settingsViewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.NextValue, k.PreviousValue, k.Reset, k.Back}
+}
+
+func (k settingsViewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.NextValue, k.PreviousValue, k.Reset, k.Back},
+	}
+}
+
+type setting struct {
+	name        string
+	description string
+	value       func(m model) string
+	// Changes the setting to the next value (`step` = 1) or previous value (`step` = -1)
+	change func(m model, step int) (model, error)
+}
+
+var symbolCounts = []int{4, 5, maxSymbolCount}
+
+var hintPolicies = []hintPolicy{NoPointsHintPolicy, LimitedHintPolicy, CostHintPolicy, PenaltyHintPolicy,
+	EarnedHintPolicy, DisabledHintPolicy}
+
+var settings = []setting{
+	{
+		name: "Game type",
+		description: "Endless: play until you end the game. Limited moves: a fixed number of moves. Daily: limited " +
+			"moves on the same board for everyone.",
+		value: func(m model) string {
+			return m.options.gameType.String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.gameType = getAdjacentElement(gameTypes, m.options.gameType, step)
+			return m, nil
+		},
+	},
+	{
+		name: "Players",
+		description: "With more than one player, players take turns in a hot-seat game, each with their own score. " +
+			"Daily challenges are single-player only.",
+		value: func(m model) string {
+			return strconv.Itoa(m.options.playerCount)
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.playerCount = getAdjacentElement(playerCounts, m.options.playerCount, step)
+			return m, nil
+		},
+	},
+	{
+		name: "Hot-seat board",
+		description: "Shared: players take turns on the same board. Separate: each player has their own board, which " +
+			"starts the same for everyone.",
+		value: func(m model) string {
+			return m.options.hotSeatBoard.String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.hotSeatBoard = getAdjacentElement(hotSeatBoards, m.options.hotSeatBoard, step)
+			return m, nil
+		},
+	},
+	{
+		name:        "Symbol set",
+		description: "The symbols shown in the grid, including any custom symbol sets from the config file.",
+		value: func(m model) string {
+			return m.symbolSet.String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.symbolSet = getAdjacentElement(m.symbolSets, m.symbolSet, step)
+			return m, nil
+		},
+	},
+	{
+		name: "Board size",
+		description: "The number of rows and columns in the grid. Takes effect from the next game (except daily " +
+			"challenges).",
+		value: func(m model) string {
+			return m.options.boardSize.String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.boardSize = getAdjacentElement(boardSizes, m.options.boardSize, step)
+			return m, nil
+		},
+	},
+	{
+		name: "Symbol count",
+		description: "Fewer different symbols make matches more likely, so the game is easier. Takes effect from the " +
+			"next game (except daily challenges).",
+		value: func(m model) string {
+			return strconv.Itoa(m.options.symbolCount)
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.symbolCount = getAdjacentElement(symbolCounts, m.options.symbolCount, step)
+			return m, nil
+		},
+	},
+	{
+		name: "Theme",
+		description: "The colours used for highlighting, borders and help text, including any custom themes from the " +
+			"themes folder.",
+		value: func(m model) string {
+			return m.theme.String()
+		},
+		change: func(m model, step int) (model, error) {
+			return applyTheme(m, getAdjacentElement(m.themes, m.theme, step)), nil
+		},
+	},
+	{
+		name:        "Animation speed",
+		description: "How quickly swaps, matches and falling symbols are animated.",
+		value: func(m model) string {
+			return m.options.animationSpeed.String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.animationSpeed = getAdjacentElement(animationSpeeds, m.options.animationSpeed, step)
+			return m, nil
+		},
+	},
+	{
+		name:        "Reduced motion",
+		description: "Replaces moving and flashing animations with highlighting.",
+		value: func(m model) string {
+			return onOffOption(m.options.reducedMotion).String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.reducedMotion = !m.options.reducedMotion
+			// Only saved when changed here, so the `--reduced-motion` flag isn't saved to the config
+			reducedMotion := m.options.reducedMotion
+			m.config.ReducedMotion = &reducedMotion
+			return m, nil
+		},
+	},
+	{
+		name: "Hint policy",
+		description: "What happens when you show a hint. " + describeHintPolicies() + " Takes effect from the next " +
+			"game. Daily and versus games use no points, unless hints are disabled.",
+		value: func(m model) string {
+			return m.options.hintPolicy.String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.hintPolicy = getAdjacentElement(hintPolicies, m.options.hintPolicy, step)
+			return m, nil
+		},
+	},
+	{
+		name: "Key profile",
+		description: "The built-in keys to use: default, vim or left-hand. Keys remapped in the config file still " +
+			"apply.",
+		value: func(m model) string {
+			return getKeyProfileName(m.config.Keys)
+		},
+		change: func(m model, step int) (model, error) {
+			keysConfig := m.config.Keys
+			keysConfig.Profile = getAdjacentElement(getKeyProfileNames(), getKeyProfileName(keysConfig), step)
+			keys, err := newKeyBindings(keysConfig)
+			if err != nil {
+				return m, err
+			}
+
+			m.keys = keys
+			m.config.Keys = keysConfig
+			return m, nil
+		},
+	},
+	{
+		name: "Input mode",
+		description: "Classic: select two points to swap them. Swipe: move the cursor freely and swap with a " +
+			"neighbour in one step.",
+		value: func(m model) string {
+			return m.options.inputMode.String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.inputMode = getAdjacentElement(inputModes, m.options.inputMode, step)
+			return m, nil
+		},
+	},
+	{
+		name: "Confirm moves",
+		description: "Whether to show the result of each swap before continuing. If off, valid swaps are applied " +
+			"straight away.",
+		value: func(m model) string {
+			return onOffOption(m.options.confirmMoves).String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.confirmMoves = !m.options.confirmMoves
+			return m, nil
+		},
+	},
+	{
+		name: "Accessibility mode",
+		description: "Marks selected and matched symbols with brackets rather than just colour, and announces game " +
+			"events in words.",
+		value: func(m model) string {
+			return onOffOption(m.options.accessible).String()
+		},
+		change: func(m model, step int) (model, error) {
+			m.options.accessible = !m.options.accessible
+			// Only saved when changed here, so the `--accessible` flag isn't saved to the config
+			accessible := m.options.accessible
+			m.config.AccessibilityMode = &accessible
+			return m, nil
+		},
+	},
+}
+
+type settingsView struct {
+	keys       settingsViewKeyMap
+	selected   int  // Index of the selected setting
+	returnView view // View to return to when the settings are closed, e.g. the title view
+}
+
+func (s *settingsView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, s.keys.Back):
+			m.view = s.returnView
+			m.help.ShowAll = false
+			return m, nil
+		case key.Matches(msg, s.keys.Up):
+			s.selected = (s.selected - 1 + len(settings)) % len(settings) // Wrap around to the bottom
+		case key.Matches(msg, s.keys.Down):
+			s.selected = (s.selected + 1) % len(settings) // Wrap around to the top
+		case key.Matches(msg, s.keys.NextValue):
+			return s.changeSetting(m, 1)
+		case key.Matches(msg, s.keys.PreviousValue):
+			return s.changeSetting(m, -1)
+		case key.Matches(msg, s.keys.Reset):
+			return s.resetSettings(m)
+		}
+	}
+
+	return m, nil
+}
+
+func (s *settingsView) changeSetting(m model, step int) (tea.Model, tea.Cmd) {
+	m, err := settings[s.selected].change(m, step)
+	if err != nil {
+		return showConfigErrorView(m, err)
+	}
+
+	// Keys may have changed (e.g. if the key profile was changed)
+	s.keys = newSettingsViewKeys(m)
+
+	return saveConfig(m)
+}
+
+// Resets every setting to its default, keeping any keys remapped in the config file
+func (s *settingsView) resetSettings(m model) (tea.Model, tea.Cmd) {
+	keysConfig := keysConfig{Bindings: m.config.Keys.Bindings}
+	keys, err := newKeyBindings(keysConfig)
+	if err != nil {
+		return showConfigErrorView(m, err)
+	}
+	m.keys = keys
+	m.config.Keys = keysConfig
+	s.keys = newSettingsViewKeys(m)
+
+	m.options = newDefaultOptions()
+	m.symbolSet = newEmojiSymbolSet()
+	m = applyTheme(m, newDefaultTheme())
+	m.config.AccessibilityMode = nil
+	m.config.ReducedMotion = nil
+
+	return saveConfig(m)
+}
+
+func (s *settingsView) draw(m model) string {
+	nameWidth := 0
+	for _, st := range settings {
+		nameWidth = maxInt(nameWidth, lipgloss.Width(st.name))
+	}
+
+	rows := make([]string, 0, len(settings))
+	for i, st := range settings {
+		name := st.name + strings.Repeat(" ", nameWidth-lipgloss.Width(st.name))
+		if i == s.selected {
+			// Arrows show the value can be changed; the marker shows the selected setting without relying on colour
+			rows = append(rows, m.theme.highlightedStyle().Render("> "+name+"   ‹ "+st.value(m)+" ›"))
+		} else {
+			rows = append(rows, "  "+name+"     "+st.value(m))
+		}
+	}
+
+	descriptionStyle := m.theme.secondaryTextStyle().Width(getMainViewWidth(m))
+	description := descriptionStyle.Render(settings[s.selected].description)
+	m.help.Width = getMainViewWidth(m)
+	helpView := m.help.View(s.keys)
+
+	if isCompactLayout(m) {
+		// Leave out the heading and spacing, so the settings fit in small windows
+		listHeight := getMainViewHeight(m) - lipgloss.Height(description) - lipgloss.Height(helpView)
+		return lipgloss.JoinVertical(lipgloss.Left,
+			drawScrolledRows(m, rows, s.selected, listHeight),
+			description,
+			helpView,
+		)
+	}
+
+	const spacingHeight = 4 // The heading and the blank lines between sections
+	listHeight := getMainViewHeight(m) - spacingHeight - lipgloss.Height(description) - lipgloss.Height(helpView)
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Bold(true).Render("Settings"),
+		"",
+		drawScrolledRows(m, rows, s.selected, listHeight),
+		"",
+		description,
+		"",
+		helpView,
+	)
+}
+
+// Draws as many rows as fit in the given height, scrolled to keep the selected row in the middle where possible, with
+// arrows showing when there are more rows above or below
+func drawScrolledRows(m model, rows []string, selected int, height int) string {
+	if len(rows) <= height {
+		return strings.Join(rows, "\n")
+	}
+
+	// Leave room for the arrows, but always show the selected row
+	visibleCount := maxInt(height-2, 1)
+	offset := maxInt(minInt(selected-visibleCount/2, len(rows)-visibleCount), 0)
+
+	moreAbove, moreBelow := "", ""
+	if offset > 0 {
+		moreAbove = "  ↑"
+	}
+	if offset+visibleCount < len(rows) {
+		moreBelow = "  ↓"
+	}
+	arrowStyle := m.theme.secondaryTextStyle()
+	lines := append([]string{arrowStyle.Render(moreAbove)}, rows[offset:offset+visibleCount]...)
+	return strings.Join(append(lines, arrowStyle.Render(moreBelow)), "\n")
+}
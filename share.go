@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dustin/go-humanize"
+	"os"
+	"strings"
+)
+
+// Summary of the finished game that can be shared with others, e.g. pasted into a chat
+// Daily challenges use the daily summary instead, so everyone's results for the day look the same
+func drawShareSummary(m model, gameStats stats) string {
+	if m.gameType == Daily && m.dailyRanked {
+		return drawDailySummary(m, gameStats)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Match Three Game - %s", m.gameType),
+		fmt.Sprintf("Score: %s in %d moves", humanize.Comma(int64(m.score)), m.moveCount),
+		fmt.Sprintf("Biggest cascade: %d", gameStats.BiggestCascade),
+		drawHintsUsed(m, gameStats),
+	}
+	if len(m.movePoints) > 0 {
+		lines = append(lines, drawMoveSquares(m.movePoints))
+	}
+	return strings.Join(append(lines, fmt.Sprintf("Seed: %d", m.gameSeed)), "\n")
+}
+
+// Draws a square for each move showing how well it scored, e.g. "🟩" for a long match, with 10 moves per row
+func drawMoveSquares(movePoints []int) string {
+	const squaresPerRow = 10
+	var squares strings.Builder
+	for i, points := range movePoints {
+		if i > 0 && i%squaresPerRow == 0 {
+			squares.WriteString("\n")
+		}
+		switch {
+		case points <= 0: // Less than zero if the move's hint cost more than it scored
+			squares.WriteString("⬜")
+		case points < 250:
+			squares.WriteString("🟨")
+		case points < 500:
+			squares.WriteString("🟩")
+		default:
+			squares.WriteString("🟪")
+		}
+	}
+	return squares.String()
+}
+
+// Returns the OSC 52 sequence that copies the text to the clipboard, which is supported by most terminals (including
+// over SSH) without needing access to the system clipboard
+func newClipboardSequence(m model, text string) osc52.Sequence {
+	sequence := osc52.New(text)
+	// Terminal multiplexers need the sequence wrapped so they pass it on to the terminal
+	if !isRemoteSession(m) && os.Getenv("TMUX") != "" {
+		sequence = sequence.Tmux()
+	} else if !isRemoteSession(m) && strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		sequence = sequence.Screen()
+	}
+	return sequence
+}
+
+// Copies the text to the clipboard straight away in SSH sessions
+// Local games copy the shared summary once the game exits instead, as the sequence could otherwise be written in the
+// middle of a frame
+func copyToClipboard(m model, text string) tea.Cmd {
+	if m.clipboard == nil {
+		return nil
+	}
+
+	sequence := newClipboardSequence(m, text)
+	w := m.clipboard
+	return func() tea.Msg {
+		// Ignore errors, as the summary is still shown in the game
+		_, _ = sequence.WriteTo(w)
+		return nil
+	}
+}
+
+// Confirms that the summary was shared, and where to find it if the terminal doesn't support copying
+func drawSharedText(m model) string {
+	if isRemoteSession(m) {
+		return "Summary copied to the clipboard (if your terminal supports it)."
+	}
+	return "The summary will be copied to the clipboard (if your terminal supports it) and printed when you quit."
+}
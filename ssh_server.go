@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/activeterm"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Generated next to the config file if it doesn't exist, so the server keeps the same host key between runs
+const sshHostKeyFileName = "ssh_host_ed25519"
+
+// Time given to sessions to finish when the server is stopped
+const sshShutdownTimeout = 30 * time.Second
+
+func getDefaultSSHHostKeyPath() (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(configPath), sshHostKeyFileName), nil
+}
+
+// Serves the game over SSH, with a separate game for each session, until the process is interrupted
+func serveSSH(address string, hostKeyPath string, c config, configErr error) error {
+	s, err := wish.NewServer(
+		wish.WithAddress(address),
+		wish.WithHostKeyPath(hostKeyPath),
+		// Players are identified by their public key rather than authenticated, so any key is accepted
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool {
+			return true
+		}),
+		// Middleware runs from last to first
+		wish.WithMiddleware(
+			bm.MiddlewareWithProgramHandler(newSessionProgramHandler(c, configErr), termenv.ANSI256),
+			activeterm.Middleware(), // The game needs a terminal, so sessions without a PTY are rejected
+			logging.Middleware(),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("could not create SSH server: %w", err)
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.ListenAndServe()
+	}()
+	log.Info("Serving the game over SSH", "address", address)
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("could not serve over SSH: %w", err)
+	case <-done:
+	}
+
+	log.Info("Stopping the SSH server")
+	ctx, cancel := context.WithTimeout(context.Background(), sshShutdownTimeout)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+		return fmt.Errorf("could not stop SSH server: %w", err)
+	}
+	return nil
+}
+
+// Creates a program for each session, with its own model and random number generator
+func newSessionProgramHandler(c config, configErr error) bm.ProgramHandler {
+	return func(s ssh.Session) *tea.Program {
+		m := initialModel(rand.New(rand.NewSource(time.Now().UnixNano())), c, configErr)
+		// Every session uses the server's config, so changes to the settings in one session shouldn't affect others
+		m.configReadOnly = true
+		m.userName = s.User()
+		m.userID = getUserID(s.PublicKey())
+		m.stats, m.statsErr = loadStats(m.userID)
+		m.statsReadOnly = m.statsErr != nil
+		output := &sessionWriter{w: s}
+		m.clipboard = output
+		m.savedGame, m.savedGameErr = loadSavedGame(m.userID)
+
+		// The same options as the middleware's own handler uses, with the output shared with the clipboard
+		return tea.NewProgram(m, tea.WithInput(s), tea.WithOutput(output), tea.WithAltScreen(), tea.WithMouseAllMotion())
+	}
+}
+
+// Writes to an SSH session one write at a time, so the clipboard (see `copyToClipboard`) and the renderer, which writes
+// each frame in one go, can share it without OSC 52 sequences ending up in the middle of a frame
+type sessionWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (sw *sessionWriter) Write(p []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.w.Write(p)
+}
+
+// Identifies a player by their public key, in a form that can be used as a file name
+func getUserID(key ssh.PublicKey) string {
+	hash := sha256.Sum256(key.Marshal())
+	return hex.EncodeToString(hash[:])
+}
+
+// Whether the game is being played over SSH (see `serveSSH`) rather than in a local terminal
+func isRemoteSession(m model) bool {
+	return m.userID != ""
+}
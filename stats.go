@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const statsFileName = "stats.json"
+
+// Directory (next to the config file) holding the stats of each player of an SSH server, which are kept separately
+const userStatsDirName = "users"
+
+// Lifetime statistics across all finished games, which are stored in the stats file next to the config file
+// The stats for a single game use the same type, so they can be added to the lifetime stats when the game ends
+type stats struct {
+	Modes          map[string]modeStats `json:"modes,omitempty"` // Keyed by game type, e.g. "Limited moves"
+	LongestMatch   int                  `json:"longestMatch"`
+	BiggestCascade int                  `json:"biggestCascade"` // Deepest cascade of a single move
+	// Number of matches of each symbol, by index in the symbol set (so it's the same whichever symbol set is used)
+	MatchesBySymbol [maxSymbolCount]int `json:"matchesBySymbol"`
+	HintsUsed       int                 `json:"hintsUsed"`
+	PlayTimeSeconds int                 `json:"playTimeSeconds"`
+	// Progress towards each achievement, by ID; only used in the lifetime stats
+	Achievements map[string]int `json:"achievements,omitempty"`
+	// Results of the ranked daily challenges, by date (e.g. "2024-06-30"); only used in the lifetime stats
+	DailyResults map[string]dailyResult `json:"dailyResults,omitempty"`
+}
+
+type modeStats struct {
+	GamesPlayed int `json:"gamesPlayed"`
+	BestScore   int `json:"bestScore"`
+	TotalScore  int `json:"totalScore"`
+}
+
+func (ms modeStats) averageScore() float64 {
+	if ms.GamesPlayed == 0 {
+		return 0
+	}
+	return float64(ms.TotalScore) / float64(ms.GamesPlayed)
+}
+
+// Combines two sets of stats, e.g. the lifetime stats and the stats of a game that just finished
+func (s stats) add(other stats) stats {
+	modes := maps.Clone(s.Modes)
+	if modes == nil {
+		modes = map[string]modeStats{} // No games have been played yet
+	}
+	for gt, otherModeStats := range other.Modes {
+		ms := modes[gt]
+		ms.GamesPlayed += otherModeStats.GamesPlayed
+		ms.BestScore = maxInt(ms.BestScore, otherModeStats.BestScore)
+		ms.TotalScore += otherModeStats.TotalScore
+		modes[gt] = ms
+	}
+	s.Modes = modes
+
+	s.LongestMatch = maxInt(s.LongestMatch, other.LongestMatch)
+	s.BiggestCascade = maxInt(s.BiggestCascade, other.BiggestCascade)
+	for i := range s.MatchesBySymbol {
+		s.MatchesBySymbol[i] += other.MatchesBySymbol[i]
+	}
+	s.HintsUsed += other.HintsUsed
+	s.PlayTimeSeconds += other.PlayTimeSeconds
+
+	return s
+}
+
+// Updates the stats of the current game for an event that happened during it
+func recordGameEvent(s stats, e gameEvent) stats {
+	switch e := e.(type) {
+	case matchEvent:
+		s.LongestMatch = maxInt(s.LongestMatch, len(e.match))
+		s.BiggestCascade = maxInt(s.BiggestCascade, e.cascadeDepth)
+		s.MatchesBySymbol[e.symbol]++
+	}
+	return s
+}
+
+// Adds the game that just finished to the lifetime stats, then writes them to the stats file
+func recordGame(m model) (model, tea.Cmd) {
+	game := m.gameStats
+	game.Modes = map[string]modeStats{
+		m.gameType.String(): {GamesPlayed: 1, BestScore: m.score, TotalScore: m.score},
+	}
+	game.PlayTimeSeconds = int(time.Since(m.gameStartTime).Seconds())
+
+	m.stats = m.stats.add(game)
+	if m.gameType == Daily && m.dailyRanked {
+		m.stats.DailyResults = withDailyResult(m.stats.DailyResults, m.dailyDate, dailyResult{
+			Score:     m.score,
+			MoveCount: m.moveCount,
+			Finished:  m.moveCount >= moveLimit,
+		})
+	}
+	m.gameStats = stats{}
+
+	return m, saveStats(m)
+}
+
+// Returns the path of the stats file of the given user, or of the local player if `userID` is empty
+func getStatsPath(userID string) (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	if userID != "" {
+		return filepath.Join(filepath.Dir(configPath), userStatsDirName, userID+".json"), nil
+	}
+	return filepath.Join(filepath.Dir(configPath), statsFileName), nil
+}
+
+// Loads the stats file; if it doesn't exist (e.g. no games have finished yet) then empty stats are returned
+func loadStats(userID string) (stats, error) {
+	var s stats
+
+	statsPath, err := getStatsPath(userID)
+	if err != nil {
+		return s, fmt.Errorf("could not find config directory: %w", err)
+	}
+
+	data, err := os.ReadFile(statsPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("could not read stats file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("invalid stats file %s: %w", statsPath, err)
+	}
+
+	return s, nil
+}
+
+func writeStats(s stats, userID string) error {
+	statsPath, err := getStatsPath(userID)
+	if err != nil {
+		return fmt.Errorf("could not find config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(statsPath), 0o755); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+	if err := os.WriteFile(statsPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("could not write stats file: %w", err)
+	}
+
+	return nil
+}
+
+type statsErrorMsg struct {
+	err error
+}
+
+// The stats aren't written if they couldn't be loaded, to avoid overwriting the existing stats
+func saveStats(m model) tea.Cmd {
+	if m.statsReadOnly {
+		return nil
+	}
+
+	s := m.stats
+	userID := m.userID
+	return func() tea.Msg {
+		if err := writeStats(s, userID); err != nil {
+			return statsErrorMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// Game types in the order they're shown, including any game types which are only in the stats file (e.g. from
+// another version of the game)
+func getStatsGameTypes(s stats) []string {
+	names := make([]string, 0, len(s.Modes))
+	for _, gt := range gameTypes {
+		names = append(names, gt.String())
+	}
+	otherNames := make([]string, 0)
+	for name := range s.Modes {
+		if !slices.Contains(names, name) {
+			otherNames = append(otherNames, name)
+		}
+	}
+	slices.Sort(otherNames)
+
+	return append(names, otherNames...)
+}
+
+// Writes the stats as CSV, with one row per statistic
+// Statistics for a particular game type or symbol (numbered from 1) have that column filled in
+func writeStatsCSV(w io.Writer, s stats) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{{"statistic", "game_type", "symbol", "value"}}
+	for _, gt := range getStatsGameTypes(s) {
+		ms := s.Modes[gt]
+		records = append(records,
+			[]string{"games_played", gt, "", strconv.Itoa(ms.GamesPlayed)},
+			[]string{"best_score", gt, "", strconv.Itoa(ms.BestScore)},
+			[]string{"average_score", gt, "", strconv.FormatFloat(ms.averageScore(), 'f', 2, 64)},
+		)
+	}
+	records = append(records,
+		[]string{"longest_match", "", "", strconv.Itoa(s.LongestMatch)},
+		[]string{"biggest_cascade", "", "", strconv.Itoa(s.BiggestCascade)},
+	)
+	for i, count := range s.MatchesBySymbol {
+		records = append(records, []string{"matches", "", strconv.Itoa(i + 1), strconv.Itoa(count)})
+	}
+	records = append(records,
+		[]string{"hints_used", "", "", strconv.Itoa(s.HintsUsed)},
+		[]string{"play_time_seconds", "", "", strconv.Itoa(s.PlayTimeSeconds)},
+	)
+	currentStreak, bestStreak := getDailyStreaks(s.DailyResults, getDailyDate(time.Now()))
+	records = append(records,
+		[]string{"daily_streak", Daily.String(), "", strconv.Itoa(currentStreak)},
+		[]string{"best_daily_streak", Daily.String(), "", strconv.Itoa(bestStreak)},
+	)
+
+	return cw.WriteAll(records)
+}
+
+// Exports the stats to the given file, as CSV if it has a ".csv" extension, otherwise as JSON
+func exportStats(s stats, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create export file: %w", err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		err = writeStatsCSV(f, s)
+	} else {
+		var data []byte
+		data, err = json.MarshalIndent(s, "", "  ")
+		if err == nil {
+			_, err = f.Write(append(data, '\n'))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("could not write export file: %w", err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Base name of the files the stats are exported to, in the current directory
+const statsExportFileName = "match-three-game-stats"
+
+// Shows the lifetime stats, returning to the current view when closed
+func showStatsView(m model) (tea.Model, tea.Cmd) {
+	m.help.ShowAll = false
+
+	s := statsView{
+		keys:       newStatsViewKeys(m),
+		returnView: m.view,
+	}
+	m.view = &s
+
+	return m, nil
+}
+
+type statsViewKeyMap struct {
+	ExportCSV  key.Binding
+	ExportJSON key.Binding
+	Back       key.Binding
+}
+
+func newStatsViewKeys(m model) statsViewKeyMap {
+	keys := statsViewKeyMap{
+		ExportCSV:  newKeyBinding(m, statsExportCSVAction, "export as CSV"),
+		ExportJSON: newKeyBinding(m, statsExportJSONAction, "export as JSON"),
+		Back:       newKeyBinding(m, statsBackAction, "back"),
+	}
+	if isRemoteSession(m) {
+		// Exports are written to the current directory, which would be on the server
+		keys.ExportCSV.SetEnabled(false)
+		keys.ExportJSON.SetEnabled(false)
+	}
+	return keys
+}
+
+func (k statsViewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.ExportCSV, k.ExportJSON, k.Back}
+}
+
+func (k statsViewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.ExportCSV, k.ExportJSON, k.Back},
+	}
+}
+
+type statsView struct {
+	keys       statsViewKeyMap
+	exportText string // Result of the last export, if any
+	returnView view   // View to return to when the stats are closed, e.g. the title view
+}
+
+func (s *statsView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, s.keys.Back):
+			m.view = s.returnView
+			m.help.ShowAll = false
+			return m, nil
+		case key.Matches(msg, s.keys.ExportCSV):
+			s.export(m, ".csv")
+		case key.Matches(msg, s.keys.ExportJSON):
+			s.export(m, ".json")
+		}
+	}
+
+	return m, nil
+}
+
+func (s *statsView) export(m model, extension string) {
+	path, err := filepath.Abs(statsExportFileName + extension)
+	if err == nil {
+		err = exportStats(m.stats, path)
+	}
+
+	if err != nil {
+		s.exportText = fmt.Sprintf("Could not export statistics: %v", err)
+	} else {
+		s.exportText = fmt.Sprintf("Exported statistics to %s.", path)
+	}
+}
+
+func (s *statsView) draw(m model) string {
+	const labelWidth = 18
+
+	gameTypeNames := getStatsGameTypes(m.stats)
+	columnWidths := make([]int, 0, len(gameTypeNames))
+	for _, name := range gameTypeNames {
+		columnWidths = append(columnWidths, maxInt(lipgloss.Width(name), 8)+3)
+	}
+	drawRow := func(label string, values []string) string {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("%-*s", labelWidth, label))
+		for i, value := range values {
+			builder.WriteString(fmt.Sprintf("%-*s", columnWidths[i], value))
+		}
+		return strings.TrimRight(builder.String(), " ")
+	}
+
+	gamesPlayed := make([]string, 0, len(gameTypeNames))
+	bestScores := make([]string, 0, len(gameTypeNames))
+	averageScores := make([]string, 0, len(gameTypeNames))
+	for _, name := range gameTypeNames {
+		ms := m.stats.Modes[name]
+		gamesPlayed = append(gamesPlayed, humanize.Comma(int64(ms.GamesPlayed)))
+		bestScores = append(bestScores, humanize.Comma(int64(ms.BestScore)))
+		averageScores = append(averageScores, humanize.Comma(int64(ms.averageScore())))
+	}
+	modesText := strings.Join([]string{
+		drawRow("", gameTypeNames),
+		drawRow("Games played", gamesPlayed),
+		drawRow("Best score", bestScores),
+		drawRow("Average score", averageScores),
+	}, "\n")
+
+	matchCounts := make([]string, 0, maxSymbolCount)
+	for i, count := range m.stats.MatchesBySymbol {
+		matchCounts = append(matchCounts, fmt.Sprintf("%s %s", m.symbolSet.getSymbolString(i),
+			humanize.Comma(int64(count))))
+	}
+	playTime := time.Duration(m.stats.PlayTimeSeconds) * time.Second
+	currentStreak, bestStreak := getDailyStreaks(m.stats.DailyResults, getDailyDate(time.Now()))
+	totalsText := strings.Join([]string{
+		drawRow("Longest match", []string{fmt.Sprintf("%d symbols", m.stats.LongestMatch)}),
+		drawRow("Biggest cascade", []string{humanize.Comma(int64(m.stats.BiggestCascade))}),
+		drawRow("Hints used", []string{humanize.Comma(int64(m.stats.HintsUsed))}),
+		drawRow("Play time", []string{playTime.String()}),
+		drawRow("Daily streak", []string{fmt.Sprintf("%s (best: %s)", formatDays(currentStreak),
+			formatDays(bestStreak))}),
+	}, "\n")
+	// Wrap the match counts next to the label, in case they don't fit on one line
+	matchesText := lipgloss.JoinHorizontal(lipgloss.Top,
+		fmt.Sprintf("%-*s", labelWidth, "Matches"),
+		lipgloss.NewStyle().Width(getMainViewWidth(m)-labelWidth).Render(strings.Join(matchCounts, "  ")),
+	)
+	totalsText = lipgloss.JoinVertical(lipgloss.Left, totalsText, matchesText)
+
+	textStyle := lipgloss.NewStyle().Width(getMainViewWidth(m))
+	var statusText string
+	switch {
+	case s.exportText != "":
+		statusText = s.exportText
+	case m.statsReadOnly:
+		statusText = fmt.Sprintf("There was a problem loading the statistics, so games won't be recorded:\n%v",
+			m.statsErr)
+	case m.statsErr != nil:
+		statusText = fmt.Sprintf("There was a problem saving the statistics:\n%v", m.statsErr)
+	}
+
+	m.help.Width = getMainViewWidth(m)
+	helpView := m.help.View(s.keys)
+
+	if isCompactLayout(m) {
+		// Leave out the heading and spacing, so the stats fit in small windows
+		lines := []string{modesText, totalsText}
+		if statusText != "" {
+			lines = append(lines, textStyle.Render(statusText))
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, append(lines, helpView)...)
+	}
+
+	heading := "Statistics"
+	if isRemoteSession(m) {
+		heading += " for " + m.userName
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(heading), "", modesText, "", totalsText, ""}
+	if statusText != "" {
+		lines = append(lines, textStyle.Render(statusText), "")
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, append(lines, helpView)...)
+}
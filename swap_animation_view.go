@@ -0,0 +1,102 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Shows point 1 and point 2 being swapped (or bouncing back if the swap would not result in a match), before the swap
+// is completed
+func showSwapAnimationView(m model, valid bool) (tea.Model, tea.Cmd) {
+	m.help.ShowAll = false
+
+	s := newSwapAnimationView(m, valid)
+	m.view = s
+
+	return m, s.player.nextFrame()
+}
+
+type swapAnimationViewKeyMap struct {
+	Pause key.Binding
+	Skip  key.Binding
+}
+
+func newSwapAnimationViewKeys(m model) swapAnimationViewKeyMap {
+	return swapAnimationViewKeyMap{
+		Pause: newPauseKeyBinding(m),
+		Skip:  newKeyBinding(m, gameSkipAction, "skip"),
+	}
+}
+
+func (s swapAnimationViewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{s.Skip, s.Pause}
+}
+
+func (s swapAnimationViewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{s.Skip, s.Pause},
+	}
+}
+
+type swapAnimationView struct {
+	keys   swapAnimationViewKeyMap
+	player animationPlayer
+	valid  bool
+}
+
+func newSwapAnimationView(m model, valid bool) swapAnimationView {
+	return swapAnimationView{
+		keys: newSwapAnimationViewKeys(m),
+		player: newAnimationPlayer(m, swapAnimation{
+			grid:   m.grid,
+			point1: m.point1,
+			point2: m.point2,
+			bounce: !valid,
+		}),
+		valid: valid,
+	}
+}
+
+func (s swapAnimationView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, s.keys.Pause):
+			return showPauseView(m)
+		case key.Matches(msg, s.keys.Skip):
+			return completeSwap(m, s.valid)
+		}
+	case animationFrameMsg:
+		if !s.player.advance(msg) {
+			return m, nil
+		}
+		if s.player.isFinished() {
+			return completeSwap(m, s.valid)
+		}
+
+		m.view = s
+		return m, s.player.nextFrame()
+	}
+
+	return m, nil
+}
+
+func (s swapAnimationView) resumeAnimation(m model) (tea.Model, tea.Cmd) {
+	cmd := s.player.resume()
+	m.view = s
+	m.help.ShowAll = false
+
+	return m, cmd
+}
+
+func (s swapAnimationView) draw(m model) string {
+	const text = "Swapping..."
+	gridText := drawGridSprites(m, s.player.sprites())
+	m.help.Width = getGridLayoutTextWidth(m, gridText)
+	helpView := m.help.View(s.keys)
+	swapText := lipgloss.JoinVertical(lipgloss.Left, text, "", helpView)
+	gridLayoutText := drawGridLayout(m, gridText, swapText)
+
+	return gridLayoutText
+}
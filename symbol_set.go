@@ -1,114 +1,143 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"github.com/charmbracelet/lipgloss"
+	"slices"
 	"strings"
 )
 
 type symbolSet interface {
 	fmt.Stringer
-	formatSymbol(symbol int) string
-	formatSymbolHighlighted(symbol int) string
+	formatSymbol(symbol int, t theme) string
+	formatSymbolHighlighted(symbol int, t theme) string
+	getSymbolString(symbol int) string // Unformatted symbol, e.g. for describing the symbol in text
 }
 
 type plainSymbolSet struct {
-	name        string
-	symbolRunes [symbolCount]rune
+	name    string
+	symbols [maxSymbolCount]string // Each symbol is usually a single rune, but can be any string of consistent width
 }
 
 func (p plainSymbolSet) String() string {
 	return p.name
 }
 
-func (p plainSymbolSet) getSymbolRune(symbol int) string {
-	emptySymbolRune := strings.Repeat(" ", lipgloss.Width(string(p.symbolRunes[0])))
+func (p plainSymbolSet) getSymbolString(symbol int) string {
+	emptySymbolString := strings.Repeat(" ", lipgloss.Width(p.symbols[0]))
 
-	if symbol < 0 || symbol >= symbolCount {
-		return emptySymbolRune
+	if symbol < 0 || symbol >= maxSymbolCount {
+		return emptySymbolString
 	}
 
-	return string(p.symbolRunes[symbol])
+	return p.symbols[symbol]
 }
 
-func (p plainSymbolSet) formatSymbol(symbol int) string {
-	return p.getSymbolRune(symbol)
+func (p plainSymbolSet) formatSymbol(symbol int, _ theme) string {
+	return p.getSymbolString(symbol)
 }
 
-func (p plainSymbolSet) formatSymbolHighlighted(symbol int) string {
-	symbolRune := p.getSymbolRune(symbol)
-	return lipgloss.NewStyle().Background(whiteColor).Render(symbolRune)
+func (p plainSymbolSet) formatSymbolHighlighted(symbol int, t theme) string {
+	symbolString := p.getSymbolString(symbol)
+	return t.symbolHighlightedStyle(t.highlightBackground).Render(symbolString)
 }
 
 func newEmojiSymbolSet() plainSymbolSet {
-	return plainSymbolSet{name: "Emojis", symbolRunes: [symbolCount]rune{'🍏', '🍇', '🍊', '🍋', '🍒', '🍓'}}
+	return plainSymbolSet{name: "Emojis", symbols: [maxSymbolCount]string{"🍏", "🍇", "🍊", "🍋", "🍒", "🍓"}}
 }
 
 type colorSymbolSet struct {
 	plainSymbolSet
-	symbolColors [symbolCount]lipgloss.AdaptiveColor
+	useThemeColors bool // If true, `symbolColors` is ignored and the theme's symbol colours are used instead
+	symbolColors   [maxSymbolCount]lipgloss.AdaptiveColor
 }
 
-func (c colorSymbolSet) getSymbolColor(symbol int) lipgloss.TerminalColor {
-	if symbol < 0 || symbol >= symbolCount {
+func (c colorSymbolSet) getSymbolColor(symbol int, t theme) lipgloss.TerminalColor {
+	if symbol < 0 || symbol >= maxSymbolCount || t.monochrome {
 		return lipgloss.NoColor{}
 	}
 
+	if c.useThemeColors {
+		return t.symbolColors[symbol]
+	}
 	return c.symbolColors[symbol]
 }
 
-func (c colorSymbolSet) formatSymbol(symbol int) string {
-	color := c.getSymbolColor(symbol)
-	symbolRune := c.getSymbolRune(symbol)
-	return lipgloss.NewStyle().Foreground(color).Render(symbolRune)
+func (c colorSymbolSet) formatSymbol(symbol int, t theme) string {
+	color := c.getSymbolColor(symbol, t)
+	symbolString := c.getSymbolString(symbol)
+	return lipgloss.NewStyle().Foreground(color).Render(symbolString)
 }
 
-func (c colorSymbolSet) formatSymbolHighlighted(symbol int) string {
-	color := c.getSymbolColor(symbol)
-	symbolRune := c.getSymbolRune(symbol)
-	return lipgloss.NewStyle().Background(color).Foreground(blackColor).Render(symbolRune)
+func (c colorSymbolSet) formatSymbolHighlighted(symbol int, t theme) string {
+	color := c.getSymbolColor(symbol, t)
+	symbolString := c.getSymbolString(symbol)
+	return t.symbolHighlightedStyle(color).Render(symbolString)
 }
 
-func newColorSymbolSet(name string, symbolRunes [symbolCount]rune) colorSymbolSet {
+func newColorSymbolSet(name string, symbols [maxSymbolCount]string) colorSymbolSet {
 	return colorSymbolSet{
-		plainSymbolSet: plainSymbolSet{name: name, symbolRunes: symbolRunes},
-		symbolColors: [symbolCount]lipgloss.AdaptiveColor{
-			{
-				Light: "22",
-				Dark:  "9",
-			},
-			{
-				Light: "202",
-				Dark:  "11",
-			},
-			{
-				Light: "5",
-				Dark:  "10",
-			},
-			{
-				Light: "6",
-				Dark:  "12",
-			},
-			{
-				Light: "21",
-				Dark:  "13",
-			},
-			{
-				Light: "124",
-				Dark:  "14",
-			},
-		},
+		plainSymbolSet: plainSymbolSet{name: name, symbols: symbols},
+		useThemeColors: true,
 	}
 }
 
 func newLetterSymbolSet() colorSymbolSet {
-	return newColorSymbolSet("Letters", [symbolCount]rune{'A', 'B', 'C', 'D', 'E', 'F'})
+	return newColorSymbolSet("Letters", [maxSymbolCount]string{"A", "B", "C", "D", "E", "F"})
 }
 
 func newShapeSymbolSet() colorSymbolSet {
-	return newColorSymbolSet("Shapes", [symbolCount]rune{'▲', '■', '●', '★', '◆', '♥'})
+	return newColorSymbolSet("Shapes", [maxSymbolCount]string{"▲", "■", "●", "★", "◆", "♥"})
 }
 
 func newNumberSymbolSet() colorSymbolSet {
-	return newColorSymbolSet("Numbers", [symbolCount]rune{'1', '2', '3', '4', '5', '6'})
+	return newColorSymbolSet("Numbers", [maxSymbolCount]string{"1", "2", "3", "4", "5", "6"})
+}
+
+var builtInSymbolSets = []symbolSet{newEmojiSymbolSet(), newShapeSymbolSet(), newLetterSymbolSet(), newNumberSymbolSet()}
+
+// Creates a symbol set defined by the user in the config file
+// If no colours are given, the symbols are drawn as they are (like the emoji symbol set)
+func newCustomSymbolSet(c symbolSetConfig) (symbolSet, error) {
+	if c.Name == "" {
+		return nil, errors.New("symbol set has no name")
+	}
+	if len(c.Symbols) != maxSymbolCount {
+		return nil, fmt.Errorf("symbol set %q has %d symbols (expected %d)", c.Name, len(c.Symbols), maxSymbolCount)
+	}
+
+	var symbols [maxSymbolCount]string
+	width := lipgloss.Width(c.Symbols[0])
+	for i, symbol := range c.Symbols {
+		// Symbols must all be the same width, otherwise the grid columns wouldn't line up
+		if w := lipgloss.Width(symbol); w == 0 || w != width {
+			return nil, fmt.Errorf("symbol %q in symbol set %q has width %d (expected all symbols to have the same, "+
+				"non-zero width)", symbol, c.Name, w)
+		}
+		if slices.Contains(symbols[:i], symbol) {
+			return nil, fmt.Errorf("symbol %q appears more than once in symbol set %q", symbol, c.Name)
+		}
+
+		symbols[i] = symbol
+	}
+
+	plain := plainSymbolSet{name: c.Name, symbols: symbols}
+	if len(c.Colors) == 0 {
+		return plain, nil
+	}
+
+	if len(c.Colors) != maxSymbolCount {
+		return nil, fmt.Errorf("symbol set %q has %d colours (expected %d)", c.Name, len(c.Colors), maxSymbolCount)
+	}
+	var symbolColors [maxSymbolCount]lipgloss.AdaptiveColor
+	for i, color := range c.Colors {
+		if color.Light == "" || color.Dark == "" {
+			return nil, fmt.Errorf("colour %d in symbol set %q needs both a light and a dark colour", i+1, c.Name)
+		}
+
+		symbolColors[i] = lipgloss.AdaptiveColor{Light: color.Light, Dark: color.Dark}
+	}
+
+	return colorSymbolSet{plainSymbolSet: plain, useThemeColors: false, symbolColors: symbolColors}, nil
 }
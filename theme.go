@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Every colour used by the UI
+type theme struct {
+	name                string
+	highlightBackground lipgloss.TerminalColor // Also used for the title bar
+	highlightForeground lipgloss.TerminalColor
+	accent              lipgloss.TerminalColor
+	helpKey             lipgloss.TerminalColor
+	helpDescription     lipgloss.TerminalColor // Also used for secondary text
+	helpSeparator       lipgloss.TerminalColor
+	symbolColors        [maxSymbolCount]lipgloss.TerminalColor
+	// Monochrome themes don't use any colours; highlighting uses reverse video instead and symbol colours are ignored
+	monochrome bool
+}
+
+func (t theme) String() string {
+	return t.name
+}
+
+func (t theme) highlightedStyle() lipgloss.Style {
+	if t.monochrome {
+		return lipgloss.NewStyle().Reverse(true).Bold(true)
+	}
+	return lipgloss.NewStyle().Background(t.highlightBackground).Foreground(t.highlightForeground).Bold(true)
+}
+
+// Style for highlighting a symbol which is drawn in the given colour
+func (t theme) symbolHighlightedStyle(symbolColor lipgloss.TerminalColor) lipgloss.Style {
+	if t.monochrome {
+		return lipgloss.NewStyle().Reverse(true)
+	}
+	return lipgloss.NewStyle().Background(symbolColor).Foreground(t.highlightForeground)
+}
+
+func (t theme) secondaryTextStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(t.helpDescription)
+}
+
+func (t theme) helpStyles() help.Styles {
+	keyStyle := lipgloss.NewStyle().Foreground(t.helpKey)
+	descriptionStyle := lipgloss.NewStyle().Foreground(t.helpDescription)
+	separatorStyle := lipgloss.NewStyle().Foreground(t.helpSeparator)
+
+	return help.Styles{
+		ShortKey:       keyStyle,
+		ShortDesc:      descriptionStyle,
+		ShortSeparator: separatorStyle,
+		Ellipsis:       separatorStyle.Copy(),
+		FullKey:        keyStyle.Copy(),
+		FullDesc:       descriptionStyle.Copy(),
+		FullSeparator:  separatorStyle.Copy(),
+	}
+}
+
+func applyTheme(m model, t theme) model {
+	m.theme = t
+	m.help.Styles = t.helpStyles()
+	return m
+}
+
+func newDefaultTheme() theme {
+	return theme{
+		name:                "Default",
+		highlightBackground: lipgloss.AdaptiveColor{Light: "8", Dark: "7"},
+		highlightForeground: lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+		accent:              lipgloss.AdaptiveColor{Light: "12", Dark: "4"},
+		// Same as the help bubble's default colours
+		helpKey:         lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"},
+		helpDescription: lipgloss.AdaptiveColor{Light: "#B2B2B2", Dark: "#4A4A4A"},
+		helpSeparator:   lipgloss.AdaptiveColor{Light: "#DDDADA", Dark: "#3C3C3C"},
+		symbolColors: [maxSymbolCount]lipgloss.TerminalColor{
+			lipgloss.AdaptiveColor{Light: "22", Dark: "9"},
+			lipgloss.AdaptiveColor{Light: "202", Dark: "11"},
+			lipgloss.AdaptiveColor{Light: "5", Dark: "10"},
+			lipgloss.AdaptiveColor{Light: "6", Dark: "12"},
+			lipgloss.AdaptiveColor{Light: "21", Dark: "13"},
+			lipgloss.AdaptiveColor{Light: "124", Dark: "14"},
+		},
+		monochrome: false,
+	}
+}
+
+func newHighContrastTheme() theme {
+	return theme{
+		name:                "Contrast",
+		highlightBackground: lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		highlightForeground: lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+		accent:              lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		helpKey:             lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		helpDescription:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		helpSeparator:       lipgloss.AdaptiveColor{Light: "8", Dark: "7"},
+		symbolColors: [maxSymbolCount]lipgloss.TerminalColor{
+			lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+			lipgloss.AdaptiveColor{Light: "4", Dark: "11"},
+			lipgloss.AdaptiveColor{Light: "2", Dark: "10"},
+			lipgloss.AdaptiveColor{Light: "5", Dark: "14"},
+			lipgloss.AdaptiveColor{Light: "0", Dark: "13"},
+			lipgloss.AdaptiveColor{Light: "6", Dark: "15"},
+		},
+		monochrome: false,
+	}
+}
+
+// Uses the Okabe-Ito palette, which stays distinguishable with deuteranopia and protanopia
+func newColorBlindSafeTheme() theme {
+	return theme{
+		name:                "Colour-blind",
+		highlightBackground: lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		highlightForeground: lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+		accent:              lipgloss.AdaptiveColor{Light: "#0072B2", Dark: "#56B4E9"},
+		helpKey:             lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"},
+		helpDescription:     lipgloss.AdaptiveColor{Light: "#B2B2B2", Dark: "#4A4A4A"},
+		helpSeparator:       lipgloss.AdaptiveColor{Light: "#DDDADA", Dark: "#3C3C3C"},
+		symbolColors: [maxSymbolCount]lipgloss.TerminalColor{
+			lipgloss.AdaptiveColor{Light: "#D55E00", Dark: "#E69F00"},
+			lipgloss.AdaptiveColor{Light: "#0072B2", Dark: "#56B4E9"},
+			lipgloss.AdaptiveColor{Light: "#009E73", Dark: "#009E73"},
+			lipgloss.AdaptiveColor{Light: "#000000", Dark: "#F0E442"},
+			lipgloss.AdaptiveColor{Light: "#CC79A7", Dark: "#CC79A7"},
+			lipgloss.AdaptiveColor{Light: "#56B4E9", Dark: "#FFFFFF"},
+		},
+		monochrome: false,
+	}
+}
+
+func newMonochromeTheme() theme {
+	return theme{
+		name:                "Monochrome",
+		highlightBackground: lipgloss.NoColor{},
+		highlightForeground: lipgloss.NoColor{},
+		accent:              lipgloss.NoColor{},
+		helpKey:             lipgloss.NoColor{},
+		helpDescription:     lipgloss.NoColor{},
+		helpSeparator:       lipgloss.NoColor{},
+		symbolColors: [maxSymbolCount]lipgloss.TerminalColor{
+			lipgloss.NoColor{},
+			lipgloss.NoColor{},
+			lipgloss.NoColor{},
+			lipgloss.NoColor{},
+			lipgloss.NoColor{},
+			lipgloss.NoColor{},
+		},
+		monochrome: true,
+	}
+}
+
+var builtInThemes = []theme{newDefaultTheme(), newHighContrastTheme(), newColorBlindSafeTheme(), newMonochromeTheme()}
+
+// Creates a theme defined by the user in the themes directory
+// Any colours that aren't given are taken from the default theme
+func newCustomTheme(c themeConfig) (theme, error) {
+	if c.Name == "" {
+		return theme{}, errors.New("theme has no name")
+	}
+
+	t := newDefaultTheme()
+	t.name = c.Name
+	t.monochrome = c.Monochrome
+
+	colors := []struct {
+		entry  string
+		config *colorConfig
+		color  *lipgloss.TerminalColor
+	}{
+		{entry: "highlightBackground", config: c.HighlightBackground, color: &t.highlightBackground},
+		{entry: "highlightForeground", config: c.HighlightForeground, color: &t.highlightForeground},
+		{entry: "accent", config: c.Accent, color: &t.accent},
+		{entry: "helpKey", config: c.HelpKey, color: &t.helpKey},
+		{entry: "helpDescription", config: c.HelpDescription, color: &t.helpDescription},
+		{entry: "helpSeparator", config: c.HelpSeparator, color: &t.helpSeparator},
+	}
+	for _, color := range colors {
+		if color.config == nil {
+			continue
+		}
+		if color.config.Light == "" || color.config.Dark == "" {
+			return theme{}, fmt.Errorf("%s in theme %q needs both a light and a dark colour", color.entry, c.Name)
+		}
+
+		*color.color = lipgloss.AdaptiveColor{Light: color.config.Light, Dark: color.config.Dark}
+	}
+
+	if len(c.SymbolColors) != 0 {
+		if len(c.SymbolColors) != maxSymbolCount {
+			return theme{}, fmt.Errorf("theme %q has %d symbol colours (expected %d)", c.Name, len(c.SymbolColors),
+				maxSymbolCount)
+		}
+		for i, color := range c.SymbolColors {
+			if color.Light == "" || color.Dark == "" {
+				return theme{}, fmt.Errorf("symbol colour %d in theme %q needs both a light and a dark colour", i+1,
+					c.Name)
+			}
+
+			t.symbolColors[i] = lipgloss.AdaptiveColor{Light: color.Light, Dark: color.Dark}
+		}
+	}
+
+	return t, nil
+}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"fmt"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"math/rand"
 	"slices"
+	"time"
 )
 
 func showTitleView(m model) (tea.Model, tea.Cmd) {
+	m = leaveVersusGame(m)
 	m.view = titleView{}
+	m.grid = nil // So the board size of the next game is used (see `getBoardSize`)
 	m.help.ShowAll = false
 
 	return m, nil
@@ -20,93 +25,214 @@ type titleViewKeyMap struct {
 	Quit            key.Binding
 	ToggleGameType  key.Binding
 	ToggleSymbolSet key.Binding
+	Settings        key.Binding
+	Stats           key.Binding
+	Achievements    key.Binding
 	Start           key.Binding
+	Continue        key.Binding
 }
 
-var titleViewKeys = titleViewKeyMap{
-	Quit: key.NewBinding(
-		key.WithKeys("q"),
-		key.WithHelp("q", "quit"),
-	),
-	ToggleGameType: key.NewBinding(
-		key.WithKeys("t"),
-		key.WithHelp("t", "change game type"),
-	),
-	ToggleSymbolSet: key.NewBinding(
-		key.WithKeys("s"),
-		key.WithHelp("s", "change symbol set"),
-	),
-	Start: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("↵", "start"),
-	),
+func newTitleViewKeys(m model) titleViewKeyMap {
+	keys := titleViewKeyMap{
+		Quit:            newKeyBinding(m, titleQuitAction, "quit"),
+		ToggleGameType:  newKeyBinding(m, titleToggleGameTypeAction, "change game type"),
+		ToggleSymbolSet: newKeyBinding(m, titleToggleSymbolSetAction, "change symbol set"),
+		Settings:        newKeyBinding(m, titleSettingsAction, "settings"),
+		Stats:           newKeyBinding(m, titleStatsAction, "statistics"),
+		Achievements:    newKeyBinding(m, titleAchievementsAction, "achievements"),
+		Start:           newKeyBinding(m, titleStartAction, "start"),
+		Continue:        newKeyBinding(m, titleContinueAction, "continue saved game"),
+	}
+	keys.Continue.SetEnabled(m.savedGame != nil)
+	return keys
 }
 
-var gameTypes = []gameType{Endless, LimitedMoves}
-var symbolSets = []symbolSet{newEmojiSymbolSet(), newShapeSymbolSet(), newLetterSymbolSet(), newNumberSymbolSet()}
+var gameTypes = []gameType{Endless, LimitedMoves, Daily}
+var inputModes = []inputMode{Classic, Swipe}
 
 func (k titleViewKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Start, k.ToggleGameType, k.ToggleSymbolSet, k.Quit}
+	return []key.Binding{k.Start, k.Continue, k.Settings, k.Stats, k.Achievements, k.Quit}
 }
 
 func (k titleViewKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Start, k.ToggleGameType, k.ToggleSymbolSet, k.Quit},
+		{k.Start, k.Continue, k.ToggleGameType, k.ToggleSymbolSet, k.Settings, k.Stats, k.Achievements, k.Quit},
 	}
 }
 
 func (tv titleView) draw(m model) string {
 	const titlePart1 = "  __  __       _       _       _____ _                   \n |  \\/  | __ _| |_ ___| |__   |_   _| |__  _ __ ___  ___ \n | |\\/| |/ _` | __/ __| '_ \\    | | | '_ \\| '__/ _ \\/ _ \\\n | |  | | (_| | || (__| | | |   | | | | | | | |  __/  __/\n |_|  |_|\\__,_|\\__\\___|_| |_|   |_| |_| |_|_|  \\___|\\___|"
 	const titlePart2 = "   ____                      \n  / ___| __ _ _ __ ___   ___ \n | |  _ / _` | '_ ` _ \\ / _ \\\n | |_| | (_| | | | | | |  __/\n  \\____|\\__,_|_| |_| |_|\\___|"
-	const text = "Press enter key to start..."
+	keys := newTitleViewKeys(m)
+	text := fmt.Sprintf("Press %s to start...", lipgloss.NewStyle().Bold(true).Render(keys.Start.Help().Key))
+	if m.savedGame != nil {
+		text = fmt.Sprintf("Press %s to start, or %s to continue your saved game...",
+			lipgloss.NewStyle().Bold(true).Render(keys.Start.Help().Key),
+			lipgloss.NewStyle().Bold(true).Render(keys.Continue.Help().Key))
+	}
+	if m.savedGameErr != nil {
+		text = lipgloss.JoinVertical(lipgloss.Center, text, m.theme.secondaryTextStyle().Render(
+			fmt.Sprintf("The saved game couldn't be continued: %v.", m.savedGameErr)))
+	}
+
+	compact := isCompactLayout(m)
+	gameTypeRadioButtons := drawRadioButtons(gameTypes, m.options.gameType, "Game type", keys.ToggleGameType, m.theme,
+		compact)
+	symbolSetRadioButtons := drawRadioButtons(m.symbolSets, m.symbolSet, "Symbol set", keys.ToggleSymbolSet, m.theme,
+		compact)
+	optionLines := []string{gameTypeRadioButtons, symbolSetRadioButtons}
+	if m.options.gameType == Daily {
+		dailyStatusStyle := m.theme.secondaryTextStyle().Width(getMainViewWidth(m)).Align(lipgloss.Center)
+		optionLines = append(optionLines, dailyStatusStyle.Render(drawDailyStatus(m)))
+	}
+	m.help.Width = getMainViewWidth(m)
+	helpView := m.help.View(keys)
+
+	if compact {
+		// Replace the ASCII art title with plain text, and wrap each line separately so lines are only wrapped if they
+		// don't fit
+		title := lipgloss.NewStyle().Bold(true).Render("MATCH THREE GAME") + " " +
+			m.theme.secondaryTextStyle().Render(version)
+		lineStyle := lipgloss.NewStyle().Width(getMainViewWidth(m)).Align(lipgloss.Center)
+		lines := append([]string{title, text}, optionLines...)
+		lines = append(lines, helpView)
+		for i, line := range lines {
+			lines[i] = lineStyle.Render(line)
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
 
-	gameTypeRadioButtons := drawRadioButtons(gameTypes, m.options.gameType, "Game type", titleViewKeys.ToggleGameType)
-	symbolSetRadioButtons := drawRadioButtons(symbolSets, m.symbolSet, "Symbol set", titleViewKeys.ToggleSymbolSet)
-	m.help.Width = m.windowSize.x - 8
-	helpView := m.help.View(titleViewKeys)
 	return lipgloss.JoinVertical(lipgloss.Center,
 		titlePart1,
 		lipgloss.JoinHorizontal(lipgloss.Bottom,
 			lipgloss.NewStyle().MarginLeft(lipgloss.Width(version)+2).MarginRight(2).Render(titlePart2),
-			secondaryTextStyle.Render(version),
+			m.theme.secondaryTextStyle().Render(version),
 		),
-		"",
 		text,
 		"",
-		gameTypeRadioButtons,
-		symbolSetRadioButtons,
+		lipgloss.JoinVertical(lipgloss.Center, optionLines...),
 		"",
 		helpView,
 	)
 }
 
+func startGame(m model) (tea.Model, tea.Cmd) {
+	m.savedGameErr = nil
+	boardSize := m.options.boardSize
+	symbolCount := m.options.symbolCount
+	var dailyCmd tea.Cmd
+	if m.options.gameType == Daily {
+		boardSize = dailyBoardSize
+		symbolCount = dailySymbolCount
+		m, dailyCmd = startDailyChallenge(m)
+	} else {
+		m.gameSeed = m.seedRand.Int63()
+	}
+	m = newGame(m, m.options.gameType, boardSize, symbolCount)
+
+	if m.options.playerCount > 1 && m.gameType != Daily { // Daily challenges are ranked, so are single-player only
+		m.hotSeatBoard = m.options.hotSeatBoard
+		m.players = newPlayers(m, m.options.playerCount)
+	}
+
+	updatedModel, cmd := beginGame(m)
+	return updatedModel, tea.Batch(dailyCmd, cmd)
+}
+
+// Starts the current game again with the same game type, board size, symbol count and players, either on the same
+// board (i.e. with the same seed) or on a new one
+func restartGame(m model, sameBoard bool) (tea.Model, tea.Cmd) {
+	bs, _ := findBoardSize(m.grid.size())
+	if !sameBoard {
+		m.gameSeed = m.seedRand.Int63()
+	}
+	playerCount := len(m.players)
+	m = newGame(m, m.gameType, bs, m.symbolCount)
+	m.dailyRanked = false // Only the first attempt is ranked, and that was used when the challenge was first started
+
+	if playerCount > 1 {
+		m.players = newPlayers(m, playerCount)
+	}
+
+	return beginGame(m)
+}
+
+// Starts playing the game set up by `newGame`
+func beginGame(m model) (tea.Model, tea.Cmd) {
+	m = emitGameEvent(m, newGameStartEvent(m))
+	if isHotSeatGame(m) {
+		m = startTurn(m, 0)
+	}
+
+	return showSelectFirstPointView(m)
+}
+
+// Resets the model for a new game using `m.gameSeed`, so games with the same seed and settings are the same
+func newGame(m model, gt gameType, bs boardSize, symbolCount int) model {
+	m.gameType = gt
+	m.symbolCount = symbolCount
+	m.rand = rand.New(rand.NewSource(m.gameSeed))
+	m.grid = newGridWithMatchesRemoved(m.rand, bs.dimensions(), m.symbolCount)
+	ensurePotentialMatch(&m.grid, m.rand, m.symbolCount)
+	m.score = 0
+	m.moveCount = 0
+	m.movePoints = nil
+	m.moves = nil
+	m.previewShown = false
+	m.moveHistorySelected = noSelectedMove
+	m.point1 = emptyVector2d
+	m.announcements = nil
+	m.gameStats = stats{}
+	hp := m.options.hintPolicy
+	if gt == Daily {
+		hp = hp.forRankedGame() // Everyone's scores for the day are compared
+	}
+	m = newGameHints(m, hp)
+	m.gameStartTime = time.Now()
+	m.players = nil
+	m.currentPlayer = noPlayer
+
+	return m
+}
+
 func getNextElement[T comparable](slice []T, element T) T {
+	return getAdjacentElement(slice, element, 1)
+}
+
+// Returns the element `step` places after `element` (or before it if `step` is negative), wrapping around at either end
+func getAdjacentElement[T comparable](slice []T, element T, step int) T {
 	index := slices.Index(slice, element)
 	if index == -1 {
 		return slice[0]
 	}
 
-	return slice[(index+1)%len(slice)]
+	return slice[((index+step)%len(slice)+len(slice))%len(slice)]
 }
 
 func (tv titleView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	keys := newTitleViewKeys(m)
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, titleViewKeys.Quit):
+		case key.Matches(msg, keys.Quit):
 			return showQuitConfirmationView(m)
 
-		case key.Matches(msg, titleViewKeys.ToggleGameType):
+		case key.Matches(msg, keys.ToggleGameType):
 			m.options.gameType = getNextElement(gameTypes, m.options.gameType)
-		case key.Matches(msg, titleViewKeys.ToggleSymbolSet):
-			m.symbolSet = getNextElement(symbolSets, m.symbolSet)
-		case key.Matches(msg, titleViewKeys.Start):
-			m.grid = newGridWithMatchesRemoved(m.rand)
-			ensurePotentialMatch(&m.grid, m.rand)
-			m.score = 0
-			m.moveCount = 0
-
-			return showSelectFirstPointView(m)
+			return saveConfig(m)
+		case key.Matches(msg, keys.ToggleSymbolSet):
+			m.symbolSet = getNextElement(m.symbolSets, m.symbolSet)
+			return saveConfig(m)
+		case key.Matches(msg, keys.Settings):
+			return showSettingsView(m)
+		case key.Matches(msg, keys.Stats):
+			return showStatsView(m)
+		case key.Matches(msg, keys.Achievements):
+			return showAchievementsView(m)
+		case key.Matches(msg, keys.Start):
+			return startGame(m)
+		case key.Matches(msg, keys.Continue):
+			return continueSavedGame(m)
 		}
 	}
 
@@ -1,12 +1,26 @@
 package main
 
-func isPointInsideGrid(p vector2d) bool {
-	return p.x >= 0 && p.x < gridWidth && p.y >= 0 && p.y < gridHeight
-}
-
 func maxInt(x, y int) int {
 	if x > y {
 		return x
 	}
 	return y
 }
+
+func minInt(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func arePointsAdjacent(p1, p2 vector2d) bool {
+	return absInt(p1.x-p2.x)+absInt(p1.y-p2.y) == 1
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dustin/go-humanize"
+	"io"
+	"net"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// In a versus game, two copies of the game are connected over TCP: one hosts and the other joins by address, then
+// both play a limited moves game on the same board at the same time, seeing each other's progress as they go
+
+// Heartbeats are sent regularly so a lost connection is noticed even if the opponent isn't making moves
+const versusHeartbeatInterval = 2 * time.Second
+
+// The connection is treated as lost if nothing is received from the opponent for this long (a variable so tests don't
+// have to wait as long)
+var versusTimeout = 10 * time.Second
+
+const versusDialTimeout = 10 * time.Second
+
+// Maximum number of messages waiting to be sent; if the opponent stops reading for this long, the connection times out
+// anyway
+const versusOutgoingBufferSize = 64
+
+type versusRole int
+
+const (
+	HostVersusRole versusRole = iota
+	JoinVersusRole
+)
+
+type versusMessageType string
+
+const (
+	// Sent by both sides once connected, so different versions of the game (which could deal different boards from
+	// the same seed) aren't matched up
+	helloVersusMessage versusMessageType = "hello"
+	// Sent by the host with the seed and settings of the game, which both sides then start
+	startVersusMessage     versusMessageType = "start"
+	progressVersusMessage  versusMessageType = "progress" // Sent after each move
+	finishedVersusMessage  versusMessageType = "finished" // Sent with the final score, once the game is over
+	heartbeatVersusMessage versusMessageType = "heartbeat"
+)
+
+// Messages are sent as JSON, one per line
+type versusMessage struct {
+	Type        versusMessageType `json:"type"`
+	Version     string            `json:"version,omitempty"`
+	Seed        int64             `json:"seed,omitempty"`
+	BoardSize   string            `json:"boardSize,omitempty"`
+	SymbolCount int               `json:"symbolCount,omitempty"`
+	HintPolicy  string            `json:"hintPolicy,omitempty"`
+	Score       int               `json:"score,omitempty"`
+	MoveCount   int               `json:"moveCount,omitempty"`
+}
+
+// Connection to the opponent, which is shared by every copy of the model
+type versusConnection struct {
+	conn     net.Conn
+	decoder  *json.Decoder
+	outgoing chan versusMessage
+	mutex    sync.Mutex // Guards `closed`, so messages aren't sent after the connection is closed
+	closed   bool
+}
+
+func newVersusConnection(conn net.Conn) *versusConnection {
+	c := &versusConnection{
+		conn:     conn,
+		decoder:  json.NewDecoder(conn),
+		outgoing: make(chan versusMessage, versusOutgoingBufferSize),
+	}
+	go c.writeMessages()
+	return c
+}
+
+// Writes queued messages in order, so e.g. the final score can't overtake the last move's score
+func (c *versusConnection) writeMessages() {
+	encoder := json.NewEncoder(c.conn)
+	for message := range c.outgoing {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(versusTimeout))
+		if err := encoder.Encode(message); err != nil {
+			// Closing the connection makes the pending `receive` fail, which reports the error
+			_ = c.conn.Close()
+			return
+		}
+	}
+	_ = c.conn.Close()
+}
+
+// Queues a message to be sent without blocking
+func (c *versusConnection) send(message versusMessage) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return
+	}
+	select {
+	case c.outgoing <- message:
+	default:
+		// The opponent has stopped reading, so the connection will time out
+	}
+}
+
+// Returns a command that waits for the next message from the opponent
+func (c *versusConnection) receive() tea.Cmd {
+	return func() tea.Msg {
+		_ = c.conn.SetReadDeadline(time.Now().Add(versusTimeout))
+		var message versusMessage
+		if err := c.decoder.Decode(&message); err != nil {
+			return versusErrorMsg{connection: c, err: describeReceiveError(err)}
+		}
+		return versusMessageMsg{connection: c, message: message}
+	}
+}
+
+// Closes the connection once any queued messages have been sent
+func (c *versusConnection) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.outgoing)
+}
+
+func describeReceiveError(err error) error {
+	var netErr net.Error
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.ECONNRESET):
+		return errors.New("the opponent left the game")
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return errors.New("the opponent stopped responding")
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("the opponent sent an invalid message: %w", err)
+	}
+	return fmt.Errorf("the connection to the opponent was lost: %w", err)
+}
+
+// Progress of the opponent in a versus game, as last reported by them
+type opponentProgress struct {
+	score     int
+	moveCount int
+	finished  bool
+}
+
+type versusState struct {
+	role       versusRole
+	address    string            // Address to host on or join; empty if not in a versus game
+	listener   net.Listener      // Only set while the host is waiting for the opponent to join
+	connection *versusConnection // Nil before connecting and after the connection is closed
+	started    bool              // Whether the game has started
+	finished   bool              // Whether this player's game is over (and their final score has been sent)
+	opponent   opponentProgress
+	err        error // Why the versus game couldn't be started, or the connection was lost
+}
+
+type versusListeningMsg struct {
+	listener net.Listener
+}
+
+type versusConnectedMsg struct {
+	connection *versusConnection
+}
+
+type versusMessageMsg struct {
+	connection *versusConnection
+	message    versusMessage
+}
+
+// Sent if the versus game couldn't be set up, or the connection was lost
+type versusErrorMsg struct {
+	connection *versusConnection // Nil if the connection wasn't made
+	err        error
+}
+
+type versusHeartbeatMsg struct{}
+
+func isVersusGame(m model) bool {
+	return m.versus.started
+}
+
+// Returns a command that starts hosting or joins the versus game, if there is one
+func connectVersus(m model) tea.Cmd {
+	switch {
+	case m.versus.address == "":
+		return nil
+	case m.versus.role == HostVersusRole:
+		return func() tea.Msg {
+			listener, err := net.Listen("tcp", m.versus.address)
+			if err != nil {
+				return versusErrorMsg{err: fmt.Errorf("could not host on %s: %w", m.versus.address, err)}
+			}
+			return versusListeningMsg{listener: listener}
+		}
+	default:
+		return func() tea.Msg {
+			conn, err := net.DialTimeout("tcp", m.versus.address, versusDialTimeout)
+			if err != nil {
+				return versusErrorMsg{err: fmt.Errorf("could not connect to %s: %w", m.versus.address, err)}
+			}
+			return versusConnectedMsg{connection: newVersusConnection(conn)}
+		}
+	}
+}
+
+// Waits for one opponent to join, then stops listening so nobody else can
+func acceptVersusConnection(listener net.Listener) tea.Cmd {
+	return func() tea.Msg {
+		conn, err := listener.Accept()
+		_ = listener.Close()
+		if err != nil {
+			return versusErrorMsg{err: fmt.Errorf("could not accept the opponent's connection: %w", err)}
+		}
+		return versusConnectedMsg{connection: newVersusConnection(conn)}
+	}
+}
+
+func versusHeartbeat() tea.Cmd {
+	return tea.Tick(versusHeartbeatInterval, func(time.Time) tea.Msg {
+		return versusHeartbeatMsg{}
+	})
+}
+
+func updateVersus(m model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case versusListeningMsg:
+		if m.versus.address == "" {
+			// The versus game was cancelled while starting to host
+			_ = msg.listener.Close()
+			return m, nil
+		}
+		m.versus.listener = msg.listener
+		return m, acceptVersusConnection(msg.listener)
+	case versusConnectedMsg:
+		if m.versus.address == "" {
+			msg.connection.close()
+			return m, nil
+		}
+		m.versus.listener = nil
+		m.versus.connection = msg.connection
+		msg.connection.send(versusMessage{Type: helloVersusMessage, Version: version})
+		return m, tea.Batch(msg.connection.receive(), versusHeartbeat())
+	case versusHeartbeatMsg:
+		if m.versus.connection == nil {
+			return m, nil
+		}
+		m.versus.connection.send(versusMessage{Type: heartbeatVersusMessage})
+		return m, versusHeartbeat()
+	case versusMessageMsg:
+		if msg.connection != m.versus.connection {
+			return m, nil
+		}
+		updatedModel, cmd := handleVersusMessage(m, msg.message)
+		m = updatedModel.(model)
+		if m.versus.connection == nil {
+			return m, cmd
+		}
+		return m, tea.Batch(cmd, m.versus.connection.receive())
+	case versusErrorMsg:
+		// Ignore errors from connections that have already been closed
+		if m.versus.address == "" || msg.connection != m.versus.connection {
+			return m, nil
+		}
+		return failVersusGame(m, msg.err)
+	}
+
+	return m, nil
+}
+
+func handleVersusMessage(m model, message versusMessage) (tea.Model, tea.Cmd) {
+	switch message.Type {
+	case helloVersusMessage:
+		if message.Version != version {
+			return failVersusGame(m, fmt.Errorf("the opponent is running version %s, but this is version %s; "+
+				"both players need the same version", message.Version, version))
+		}
+		if m.versus.role == HostVersusRole {
+			seed := m.seedRand.Int63()
+			hp := m.options.hintPolicy.forRankedGame() // Both players' scores are compared
+			m.versus.connection.send(versusMessage{
+				Type:        startVersusMessage,
+				Seed:        seed,
+				BoardSize:   m.options.boardSize.String(),
+				SymbolCount: m.options.symbolCount,
+				HintPolicy:  hp.String(),
+			})
+			return startVersusGame(m, seed, m.options.boardSize, m.options.symbolCount, hp)
+		}
+	case startVersusMessage:
+		bs, ok := findByName(boardSizes, message.BoardSize)
+		hp, ok2 := findByName(hintPolicies, message.HintPolicy)
+		if !ok || !ok2 || !slices.Contains(symbolCounts, message.SymbolCount) {
+			return failVersusGame(m, fmt.Errorf("the host sent invalid settings (board size %q, %d symbols, hint "+
+				"policy %q)", message.BoardSize, message.SymbolCount, message.HintPolicy))
+		}
+		return startVersusGame(m, message.Seed, bs, message.SymbolCount, hp)
+	case progressVersusMessage:
+		if !m.versus.opponent.finished {
+			m.versus.opponent = opponentProgress{score: message.Score, moveCount: message.MoveCount}
+		}
+	case finishedVersusMessage:
+		m.versus.opponent = opponentProgress{score: message.Score, moveCount: message.MoveCount, finished: true}
+		if m.versus.finished {
+			// Both players are finished, so the result is shown on both sides and the connection is no longer needed
+			m = closeVersusConnection(m)
+		}
+	}
+
+	// Other messages (i.e. heartbeats) only keep the connection alive
+	return m, nil
+}
+
+// Starts the versus game with the host's settings, so both players play the same game
+func startVersusGame(m model, seed int64, bs boardSize, symbolCount int, hp hintPolicy) (tea.Model, tea.Cmd) {
+	m.gameSeed = seed
+	m = newGame(m, LimitedMoves, bs, symbolCount)
+	m = newGameHints(m, hp)
+	m.versus.started = true
+	m = emitGameEvent(m, newGameStartEvent(m))
+
+	return showSelectFirstPointView(m)
+}
+
+// Sends the player's score so far, so the opponent can see how they're doing
+func sendVersusProgress(m model) {
+	if m.versus.connection != nil {
+		m.versus.connection.send(versusMessage{Type: progressVersusMessage, Score: m.score, MoveCount: m.moveCount})
+	}
+}
+
+// Sends the player's final score; the result is shown once the opponent has finished too
+func finishVersusGame(m model) model {
+	m.versus.finished = true
+	if m.versus.connection != nil {
+		m.versus.connection.send(versusMessage{Type: finishedVersusMessage, Score: m.score, MoveCount: m.moveCount})
+	}
+	if m.versus.opponent.finished {
+		m = closeVersusConnection(m)
+	}
+	return m
+}
+
+func failVersusGame(m model, err error) (tea.Model, tea.Cmd) {
+	m = closeVersusConnection(m)
+	m.versus.err = err
+
+	// The result can still be shown if the opponent finished before leaving
+	if !m.versus.started || m.versus.finished || m.versus.opponent.finished {
+		return m, nil
+	}
+	return showGameOverView(m, "The game was ended early.")
+}
+
+func closeVersusConnection(m model) model {
+	if m.versus.listener != nil {
+		_ = m.versus.listener.Close()
+		m.versus.listener = nil
+	}
+	if m.versus.connection != nil {
+		m.versus.connection.close()
+		m.versus.connection = nil
+	}
+	return m
+}
+
+// Closes the connection and returns to single-player games
+func leaveVersusGame(m model) model {
+	m = closeVersusConnection(m)
+	m.versus = versusState{}
+	return m
+}
+
+func getPort(addr net.Addr) string {
+	_, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return port
+}
+
+// Shows how the opponent is doing, beside the grid
+func drawOpponentProgress(m model) string {
+	opponent := m.versus.opponent
+	score := humanize.Comma(int64(opponent.score))
+	switch {
+	case opponent.finished:
+		return fmt.Sprintf("Opponent: %s (finished)", score)
+	case m.versus.err != nil:
+		return fmt.Sprintf("Opponent: %s (left)", score)
+	}
+	return fmt.Sprintf("Opponent: %s (%d moves left)", score, moveLimit-opponent.moveCount)
+}
+
+// Describes the result of the versus game, once both players have finished
+func drawVersusResult(m model) string {
+	opponent := m.versus.opponent
+	if !opponent.finished {
+		if m.versus.err != nil {
+			return fmt.Sprintf("No result, as %v.", m.versus.err)
+		}
+		return "Waiting for the opponent to finish..."
+	}
+
+	scores := fmt.Sprintf("%s to %s", humanize.Comma(int64(m.score)), humanize.Comma(int64(opponent.score)))
+	switch {
+	case m.score > opponent.score:
+		return fmt.Sprintf("You win, %s!", scores)
+	case m.score < opponent.score:
+		return fmt.Sprintf("You lose, %s.", scores)
+	}
+	return fmt.Sprintf("It's a draw, %s.", scores)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Shows the versus game being set up, until both sides are connected and the game starts
+func showVersusLobbyView(m model, role versusRole, address string) model {
+	m.versus = versusState{role: role, address: address}
+	m.view = versusLobbyView{}
+	m.help.ShowAll = false
+
+	return m
+}
+
+type versusLobbyViewKeyMap struct {
+	Cancel key.Binding
+	Quit   key.Binding
+}
+
+func newVersusLobbyViewKeys(m model) versusLobbyViewKeyMap {
+	return versusLobbyViewKeyMap{
+		Cancel: newKeyBinding(m, versusLobbyCancelAction, "title screen"),
+		Quit:   newKeyBinding(m, versusLobbyQuitAction, "quit"),
+	}
+}
+
+func (k versusLobbyViewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Cancel, k.Quit}
+}
+
+func (k versusLobbyViewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Cancel, k.Quit},
+	}
+}
+
+type versusLobbyView struct{}
+
+func (v versusLobbyView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	keys := newVersusLobbyViewKeys(m)
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Cancel):
+			return showTitleView(m)
+		case key.Matches(msg, keys.Quit):
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (v versusLobbyView) draw(m model) string {
+	var text string
+	switch {
+	case m.versus.err != nil:
+		text = fmt.Sprintf("The versus game couldn't be started: %v.", m.versus.err)
+	case m.versus.connection != nil:
+		text = "Connected! Waiting for the game to start..."
+	case m.versus.listener != nil:
+		text = fmt.Sprintf("Hosting on %s. Waiting for an opponent to join...\n\n"+
+			"They can join by running the game with -join <your address>:%s", m.versus.listener.Addr(),
+			getPort(m.versus.listener.Addr()))
+	case m.versus.role == HostVersusRole:
+		text = fmt.Sprintf("Starting to host on %s...", m.versus.address)
+	default:
+		text = fmt.Sprintf("Connecting to %s...", m.versus.address)
+	}
+
+	m.help.Width = getMainViewWidth(m)
+	helpView := m.help.View(newVersusLobbyViewKeys(m))
+
+	if isCompactLayout(m) {
+		// Leave out the heading and spacing, so the text fits in small windows
+		return lipgloss.NewStyle().Width(getMainViewWidth(m)).Render(lipgloss.JoinVertical(lipgloss.Left, text,
+			helpView))
+	}
+
+	return lipgloss.NewStyle().Width(getMainViewWidth(m)).Render(lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Bold(true).Render("Versus"),
+		"",
+		text,
+		"",
+		helpView,
+	))
+}
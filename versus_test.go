@@ -0,0 +1,150 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"math/rand"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newVersusTestModel(role versusRole, address string, hp hintPolicy) model {
+	m := initialModel(rand.New(rand.NewSource(time.Now().UnixNano())), config{}, nil)
+	m.options.hintPolicy = hp
+	m.versus = versusState{role: role, address: address}
+	return m
+}
+
+func updateVersusTestModel(t *testing.T, m model, cmd tea.Cmd) model {
+	t.Helper()
+	updatedModel, _ := updateVersus(m, cmd())
+	return updatedModel.(model)
+}
+
+// Starts hosting on a free loopback port, returning the host and the address to join
+func hostVersusTestGame(t *testing.T, hp hintPolicy) (model, string) {
+	t.Helper()
+	host := newVersusTestModel(HostVersusRole, "127.0.0.1:0", hp)
+	host = updateVersusTestModel(t, host, connectVersus(host))
+	if host.versus.err != nil {
+		t.Fatalf("could not host: %v", host.versus.err)
+	}
+	return host, host.versus.listener.Addr().String()
+}
+
+// Accepts the opponent's connection in the background, as it only returns once the opponent has connected
+func acceptVersusTestConnection(host model) <-chan tea.Msg {
+	accepted := make(chan tea.Msg, 1)
+	go func() {
+		accepted <- acceptVersusConnection(host.versus.listener)()
+	}()
+	return accepted
+}
+
+func receiveVersusTestMessage(t *testing.T, m model) model {
+	t.Helper()
+	return updateVersusTestModel(t, m, m.versus.connection.receive())
+}
+
+// Connects a host and an opponent, then exchanges the hello and start messages so both start the game
+func startVersusTestGame(t *testing.T, hostHintPolicy, joinHintPolicy hintPolicy) (model, model) {
+	t.Helper()
+	host, address := hostVersusTestGame(t, hostHintPolicy)
+	accepted := acceptVersusTestConnection(host)
+
+	join := newVersusTestModel(JoinVersusRole, address, joinHintPolicy)
+	join = updateVersusTestModel(t, join, connectVersus(join))
+	host = updateVersusTestModel(t, host, func() tea.Msg { return <-accepted })
+	if host.versus.connection == nil || join.versus.connection == nil {
+		t.Fatalf("could not connect (host: %v, join: %v)", host.versus.err, join.versus.err)
+	}
+
+	host = receiveVersusTestMessage(t, host) // Hello, after which the host sends the start message
+	join = receiveVersusTestMessage(t, join) // Hello
+	join = receiveVersusTestMessage(t, join) // Start
+	return host, join
+}
+
+func TestVersusHandshake(t *testing.T) {
+	host, join := startVersusTestGame(t, DisabledHintPolicy, LimitedHintPolicy)
+	defer closeVersusConnection(host)
+	defer closeVersusConnection(join)
+
+	if !isVersusGame(host) || !isVersusGame(join) {
+		t.Fatalf("game not started (host: %v, join: %v)", host.versus.err, join.versus.err)
+	}
+	if host.gameSeed != join.gameSeed || !slices.EqualFunc(host.grid, join.grid, slices.Equal[[]int]) {
+		t.Errorf("host and opponent have different boards")
+	}
+	if host.gameType != LimitedMoves || join.gameType != LimitedMoves {
+		t.Errorf("game types are %v and %v, want %v", host.gameType, join.gameType, LimitedMoves)
+	}
+	// The opponent uses the host's hint policy rather than their own
+	if host.hintPolicy != DisabledHintPolicy || join.hintPolicy != DisabledHintPolicy {
+		t.Errorf("hint policies are %v and %v, want %v", host.hintPolicy, join.hintPolicy, DisabledHintPolicy)
+	}
+}
+
+func TestVersusVersionMismatch(t *testing.T) {
+	host, address := hostVersusTestGame(t, NoPointsHintPolicy)
+	accepted := acceptVersusTestConnection(host)
+
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(`{"type": "hello", "version": "0.0.0-other"}` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	host = updateVersusTestModel(t, host, func() tea.Msg { return <-accepted })
+	host = receiveVersusTestMessage(t, host)
+	if isVersusGame(host) {
+		t.Errorf("game started with a different version")
+	}
+	if host.versus.err == nil || !strings.Contains(host.versus.err.Error(), "0.0.0-other") {
+		t.Errorf("error is %v, want a version mismatch", host.versus.err)
+	}
+	if host.versus.connection != nil {
+		t.Errorf("connection not closed")
+	}
+}
+
+func TestVersusDisconnect(t *testing.T) {
+	host, join := startVersusTestGame(t, NoPointsHintPolicy, NoPointsHintPolicy)
+	defer closeVersusConnection(host)
+
+	leaveVersusGame(join)
+	host = receiveVersusTestMessage(t, host)
+	if host.versus.err == nil || host.versus.err.Error() != "the opponent left the game" {
+		t.Errorf("error is %v, want the opponent to have left", host.versus.err)
+	}
+	if _, ok := host.view.(gameOverView); !ok {
+		t.Errorf("view is %T, want the game over view", host.view)
+	}
+}
+
+func TestVersusTimeout(t *testing.T) {
+	previousTimeout := versusTimeout
+	versusTimeout = 100 * time.Millisecond
+	defer func() { versusTimeout = previousTimeout }()
+
+	host, address := hostVersusTestGame(t, NoPointsHintPolicy)
+	accepted := acceptVersusTestConnection(host)
+
+	// Connect, but never send anything
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	host = updateVersusTestModel(t, host, func() tea.Msg { return <-accepted })
+	host = receiveVersusTestMessage(t, host)
+	if host.versus.err == nil || host.versus.err.Error() != "the opponent stopped responding" {
+		t.Errorf("error is %v, want the opponent to have stopped responding", host.versus.err)
+	}
+}
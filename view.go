@@ -9,11 +9,21 @@ type view interface {
 	draw(m model) string
 }
 
+// A view with an animation, which is paused while a modal is shown on top of it
+type animatedView interface {
+	view
+	resumeAnimation(m model) (tea.Model, tea.Cmd)
+}
+
 func showPreviousView(m model) (tea.Model, tea.Cmd) {
 	if m.previousView == nil {
 		return m, nil
 	}
 
+	if v, ok := m.previousView.(animatedView); ok {
+		return v.resumeAnimation(m)
+	}
+
 	m.view = m.previousView
 	m.help.ShowAll = false
 
@@ -28,7 +38,7 @@ func showModal(m model, v view) (tea.Model, tea.Cmd) {
 	// (e.g. window too small view); modals store each other as the previous view, so it's impossible to escape from the
 	// modals
 	switch m.view.(type) {
-	case windowTooSmallView, endGameConfirmationView, quitConfirmationView:
+	case windowTooSmallView, endGameConfirmationView, quitConfirmationView, configErrorView:
 	default:
 		m.previousView = m.view
 	}
@@ -15,11 +15,10 @@ type windowTooSmallViewKeyMap struct {
 	Quit key.Binding
 }
 
-var windowTooSmallViewKeys = windowTooSmallViewKeyMap{
-	Quit: key.NewBinding(
-		key.WithKeys("q"),
-		key.WithHelp("q", "quit"),
-	),
+func newWindowTooSmallViewKeys(m model) windowTooSmallViewKeyMap {
+	return windowTooSmallViewKeyMap{
+		Quit: newKeyBinding(m, windowTooSmallQuitAction, "quit"),
+	}
 }
 
 func (w windowTooSmallViewKeyMap) ShortHelp() []key.Binding {
@@ -38,7 +37,7 @@ func (w windowTooSmallView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, windowTooSmallViewKeys.Quit):
+		case key.Matches(msg, newWindowTooSmallViewKeys(m).Quit):
 			return showQuitConfirmationView(m)
 		}
 	}
@@ -47,13 +46,14 @@ func (w windowTooSmallView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 }
 
 func (w windowTooSmallView) draw(m model) string {
+	minWindowSize := getMinWindowSize(m)
 	text := fmt.Sprintf("Window is too small. Please resize the window to at least %dx%d (currently %dx%d).",
 		minWindowSize.x, minWindowSize.y, m.windowSize.x, m.windowSize.y)
 
-	m.help.Width = m.windowSize.x - 8
-	helpView := m.help.View(windowTooSmallViewKeys)
+	m.help.Width = getMainViewWidth(m)
+	helpView := m.help.View(newWindowTooSmallViewKeys(m))
 
 	return lipgloss.NewStyle().
-		Width(m.windowSize.x - 8).
+		Width(getMainViewWidth(m)).
 		Render(lipgloss.JoinVertical(lipgloss.Left, text, "", helpView))
 }